@@ -0,0 +1,117 @@
+package plog
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/pd93/plog/formatters"
+)
+
+// newBenchmarkLogger creates a logger that writes to nowhere.
+func newBenchmarkLogger(opts ...LoggerOption) *Logger {
+	return NewLogger(append([]LoggerOption{
+		WithOutput(ioutil.Discard),
+		WithLogLevel(InfoLevel),
+	}, opts...)...)
+}
+
+func BenchmarkDisabled(b *testing.B) {
+	logger := newBenchmarkLogger()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.TDebug(Tags{"tag1", "tag2"}, "Test string", 123, 4.5, true)
+	}
+}
+
+func BenchmarkDisabledFormatted(b *testing.B) {
+	logger := newBenchmarkLogger()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.TDebugf(Tags{"tag1", "tag2"}, "%s %d %.1f %t", "Test string", 123, 4.5, true)
+	}
+}
+
+func BenchmarkDisabledGlobal(b *testing.B) {
+	AddLogger("benchmark", newBenchmarkLogger())
+	defer DeleteLogger("benchmark")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		TDebugf(Tags{"tag1", "tag2"}, "%s %d %.1f %t", "Test string", 123, 4.5, true)
+	}
+}
+
+func BenchmarkText(b *testing.B) {
+	logger := newBenchmarkLogger(WithColorLogging(false))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.TInfo(Tags{"tag1", "tag2"}, "Test string", 123, 4.5, true)
+	}
+}
+
+func BenchmarkTextColor(b *testing.B) {
+	logger := newBenchmarkLogger()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.TInfo(Tags{"tag1", "tag2"}, "Test string", 123, 4.5, true)
+	}
+}
+
+func BenchmarkJSON(b *testing.B) {
+	logger := newBenchmarkLogger(WithRecordFormatter(formatters.JSONRecord), WithColorLogging(false))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		logger.TInfo(Tags{"tag1", "tag2"}, "Test string", 123, 4.5, true)
+	}
+}
+
+func BenchmarkParallel(b *testing.B) {
+	logger := newBenchmarkLogger()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			logger.TInfo(Tags{"tag1", "tag2"}, "Test string", 123, 4.5, true)
+		}
+	})
+}
+
+type allocationTest struct {
+	name      string
+	logger    *Logger
+	logLevel  LogLevel
+	maxAllocs float64
+}
+
+func TestAllocations(t *testing.T) {
+
+	if raceEnabled {
+		t.Skip("Allocations cannot be counted when the race detector is enabled")
+	}
+
+	tests := []allocationTest{
+		{"Disabled", newBenchmarkLogger(), DebugLevel, 0},
+		{"Text", newBenchmarkLogger(WithColorLogging(false)), InfoLevel, 0},
+		{"TextColor", newBenchmarkLogger(), InfoLevel, 0},
+		{"JSON", newBenchmarkLogger(WithRecordFormatter(formatters.JSONRecord), WithColorLogging(false)), InfoLevel, 0},
+	}
+
+	// Loop through the tests
+	for _, test := range tests {
+
+		// Count the allocations made when writing a log
+		allocs := testing.AllocsPerRun(100, func() {
+			test.logger.write(newTLog(test.logLevel, Tags{"tag1", "tag2"}, "Test string", 123, 4.5, true))
+		})
+
+		// Check if the number of allocations is correct
+		if allocs > test.maxAllocs {
+			t.Errorf("[%s] Too many allocations. Expected at most %.0f, received %.1f", test.name, test.maxAllocs, allocs)
+		}
+	}
+}
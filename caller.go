@@ -0,0 +1,37 @@
+package plog
+
+import (
+	"path"
+	"runtime"
+	"strings"
+
+	"github.com/pd93/plog/formatters"
+)
+
+// The maximum number of stack frames searched when finding the caller of a log.
+const maxCallerDepth = 32
+
+// The directory of the plog package. Frames in this directory are skipped when finding the caller of a log.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return path.Dir(file)
+}()
+
+// findCaller will return the location of the first function outside of plog in the current call stack.
+// This means that logs written through global functions, loggers and timers all report the code that wrote them.
+func findCaller() formatters.Caller {
+
+	var pcs [maxCallerDepth]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+
+	// Loop through the frames and skip any that are inside plog (except its tests)
+	for {
+		frame, more := frames.Next()
+		if path.Dir(frame.File) != packageDir || strings.HasSuffix(frame.File, "_test.go") {
+			return formatters.Caller{File: frame.File, Line: frame.Line, Function: frame.Function}
+		}
+		if !more {
+			return formatters.Caller{}
+		}
+	}
+}
@@ -0,0 +1,7 @@
+package plog
+
+import "time"
+
+// A Clock is a function that returns the current time.
+// The default clock for loggers and files is `time.Now`.
+type Clock func() time.Time
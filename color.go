@@ -3,8 +3,10 @@ package plog
 import (
 	"fmt"
 	"regexp"
-	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/pd93/plog/formatters"
 )
 
 // Attribute defines a single SGR Code
@@ -15,6 +17,7 @@ const (
 	// Reset will remove any attributes
 	Reset Attribute = 0
 	// NoReset will stop the Color() function from adding a trailing Reset attribute
+	// It is ignored by color maps and themes, which always reset their styles so that the color does not spread to the rest of the log
 	NoReset Attribute = -1
 )
 
@@ -81,22 +84,28 @@ const (
 
 var colorRegex = regexp.MustCompile(`\x1b\[(?:\d+;?)+m`)
 
+// Colored is a string that has been colored by the Color function.
+// Loggers with sanitization enabled keep the colors of these strings (if color logging is enabled), but strip escape sequences from any other variable.
+type Colored = formatters.Colored
+
 // Color allows you to log something with the given attributes.
 // If you pass the Reset attribute, all color will be stripped from the string.
-func Color(message string, attributes ...Attribute) string {
+// Use `string(Color(...))` to use the colored text as a plain string, but note that sanitization will then strip its colors.
+func Color(message string, attributes ...Attribute) Colored {
 
 	var resetDisabled bool
 	var reset string
-	strAttributes := make([]string, len(attributes))
+	profile := CurrentColorProfile()
+	strAttributes := make([]string, 0, len(attributes))
 
 	// Loop over the attributes
-	for i, attribute := range attributes {
+	for _, attribute := range attributes {
 
 		switch attribute {
 
 		// Strip all color strings
 		case Reset:
-			return colorRegex.ReplaceAllString(message, "")
+			return Colored(colorRegex.ReplaceAllString(message, ""))
 
 		// Do not add the reset attribute
 		case NoReset:
@@ -104,7 +113,7 @@ func Color(message string, attributes ...Attribute) string {
 
 		// Add the attribute to the format as a string
 		default:
-			strAttributes[i] = strconv.Itoa(int(attribute))
+			strAttributes = append(strAttributes, attribute.code(profile))
 		}
 	}
 
@@ -116,5 +125,90 @@ func Color(message string, attributes ...Attribute) string {
 		reset = fmt.Sprintf("\x1b[%dm", Reset)
 	}
 
-	return fmt.Sprintf("\x1b[%sm%s%s", format, message, reset)
+	return Colored(fmt.Sprintf("\x1b[%sm%s%s", format, message, reset))
+}
+
+// The maximum number of attributes in a style that can be cached.
+const maxCachedAttributes = 8
+
+// A styleKey is used to look up a cached style without allocating.
+type styleKey struct {
+	profile    ColorProfile
+	n          int
+	attributes [maxCachedAttributes]Attribute
+}
+
+// Styles are cached so that they only need to be rendered once.
+var (
+	styleCache      = make(map[styleKey]formatters.Style)
+	styleCacheMutex sync.RWMutex
+)
+
+// newStyle will pre-render the given attributes into a style that formatters can apply.
+// If no attributes are given, an empty style is returned and text will not be colored.
+func newStyle(attributes []Attribute) formatters.Style {
+
+	if len(attributes) == 0 {
+		return ""
+	}
+
+	profile := CurrentColorProfile()
+
+	// Don't cache unusually long lists of attributes
+	if len(attributes) > maxCachedAttributes {
+		return renderStyle(attributes, profile)
+	}
+
+	// Check if the style has already been rendered for the current color profile
+	key := styleKey{profile: profile, n: len(attributes)}
+	copy(key.attributes[:], attributes)
+
+	styleCacheMutex.RLock()
+	style, ok := styleCache[key]
+	styleCacheMutex.RUnlock()
+
+	if ok {
+		return style
+	}
+
+	// Render the style and add it to the cache
+	style = renderStyle(attributes, profile)
+
+	styleCacheMutex.Lock()
+	styleCache[key] = style
+	styleCacheMutex.Unlock()
+
+	return style
+}
+
+// renderStyle will convert the given attributes into an SGR escape sequence.
+// Extended colors are downgraded if the color profile cannot display them.
+// As with Color(), the Reset attribute removes the style. NoReset is ignored because styles always end with a reset.
+func renderStyle(attributes []Attribute, profile ColorProfile) formatters.Style {
+
+	strAttributes := make([]string, 0, len(attributes))
+
+	// Loop over the attributes and add them to the format as strings
+	for _, attribute := range attributes {
+
+		switch attribute {
+
+		// Don't style the text
+		case Reset:
+			return ""
+
+		// Styles are always reset, so there is nothing to disable
+		case NoReset:
+
+		// Add the attribute to the format as a string
+		default:
+			strAttributes = append(strAttributes, attribute.code(profile))
+		}
+	}
+
+	if len(strAttributes) == 0 {
+		return ""
+	}
+
+	return formatters.Style(fmt.Sprintf("\x1b[%sm", strings.Join(strAttributes, ";")))
 }
@@ -0,0 +1,272 @@
+package plog
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Default colors:
+const (
+	// FgDefault will reset the foreground color to the terminal's default
+	FgDefault Attribute = 39
+	// BgDefault will reset the background color to the terminal's default
+	BgDefault Attribute = 49
+)
+
+// Extended colors are stored in a single attribute using these flags.
+// The lower 24 bits hold either a 256 color palette index or an RGB value.
+const (
+	extendedColor   Attribute = 1 << 24
+	backgroundColor Attribute = 1 << 25
+	rgbColor        Attribute = 1 << 26
+)
+
+// A ColorProfile describes the range of colors that a terminal can display.
+type ColorProfile int32
+
+// Available color profiles:
+const (
+	// ANSI16 terminals can only display the 16 basic colors
+	ANSI16 ColorProfile = iota
+	// ANSI256 terminals can display the 256 color palette
+	ANSI256
+	// TrueColor terminals can display any 24-bit RGB color
+	TrueColor
+)
+
+// The profile used to render extended colors.
+var colorProfile = int32(DetectColorProfile())
+
+// The RGB values of the 16 basic colors (based on xterm's defaults).
+var ansi16Palette = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// The intensity of each step in the 6x6x6 color cube of the 256 color palette.
+var ansi256Levels = [6]int{0, 95, 135, 175, 215, 255}
+
+//
+// Constructors
+//
+
+// Fg256 will return an attribute that sets the foreground to a color from the 256 color palette.
+func Fg256(n uint8) Attribute {
+	return extendedColor | Attribute(n)
+}
+
+// Bg256 will return an attribute that sets the background to a color from the 256 color palette.
+func Bg256(n uint8) Attribute {
+	return extendedColor | backgroundColor | Attribute(n)
+}
+
+// FgRGB will return an attribute that sets the foreground to a 24-bit color.
+func FgRGB(r, g, b uint8) Attribute {
+	return extendedColor | rgbColor | Attribute(r)<<16 | Attribute(g)<<8 | Attribute(b)
+}
+
+// BgRGB will return an attribute that sets the background to a 24-bit color.
+func BgRGB(r, g, b uint8) Attribute {
+	return FgRGB(r, g, b) | backgroundColor
+}
+
+// FgHex will return an attribute that sets the foreground to a 24-bit color given as a hex string (e.g. "#ff8800" or "#f80").
+// If the string is not a valid color, the terminal's default foreground color is used.
+func FgHex(hex string) Attribute {
+	r, g, b, ok := parseHex(hex)
+	if !ok {
+		return FgDefault
+	}
+	return FgRGB(r, g, b)
+}
+
+// BgHex will return an attribute that sets the background to a 24-bit color given as a hex string (e.g. "#ff8800" or "#f80").
+// If the string is not a valid color, the terminal's default background color is used.
+func BgHex(hex string) Attribute {
+	r, g, b, ok := parseHex(hex)
+	if !ok {
+		return BgDefault
+	}
+	return BgRGB(r, g, b)
+}
+
+//
+// Color profiles
+//
+
+// DetectColorProfile will return the color profile of the terminal based on the environment.
+// COLORTERM=truecolor (or 24bit) indicates a 24-bit terminal and a TERM containing '256color' indicates a 256 color terminal.
+func DetectColorProfile() ColorProfile {
+
+	switch colorTerm := strings.ToLower(os.Getenv("COLORTERM")); colorTerm {
+	case "truecolor", "24bit":
+		return TrueColor
+	}
+
+	switch term := strings.ToLower(os.Getenv("TERM")); {
+	case strings.Contains(term, "truecolor"), strings.Contains(term, "24bit"), strings.Contains(term, "direct"):
+		return TrueColor
+	case strings.Contains(term, "256color"):
+		return ANSI256
+	}
+
+	return ANSI16
+}
+
+// SetColorProfile will set the color profile used to render extended colors.
+// By default, the profile is detected from the environment when the program starts (See `DetectColorProfile`).
+// Colors that the profile cannot display are downgraded to the nearest color that it can.
+func SetColorProfile(profile ColorProfile) {
+	atomic.StoreInt32(&colorProfile, int32(profile))
+}
+
+// CurrentColorProfile will return the color profile used to render extended colors.
+func CurrentColorProfile() ColorProfile {
+	return ColorProfile(atomic.LoadInt32(&colorProfile))
+}
+
+//
+// Rendering
+//
+
+// code will return the SGR code of the attribute for the given color profile.
+func (attribute Attribute) code(profile ColorProfile) string {
+
+	// Basic attributes are already SGR codes
+	if attribute&extendedColor == 0 {
+		return strconv.Itoa(int(attribute))
+	}
+
+	background := attribute&backgroundColor != 0
+	prefix := "38;"
+	if background {
+		prefix = "48;"
+	}
+
+	// 24-bit colors
+	if attribute&rgbColor != 0 {
+		r, g, b := int(attribute>>16&0xff), int(attribute>>8&0xff), int(attribute&0xff)
+		switch profile {
+		case TrueColor:
+			return prefix + "2;" + strconv.Itoa(r) + ";" + strconv.Itoa(g) + ";" + strconv.Itoa(b)
+		case ANSI256:
+			return prefix + "5;" + strconv.Itoa(rgbTo256(r, g, b))
+		default:
+			return ansi16Code(nearestANSI16(r, g, b), background)
+		}
+	}
+
+	// 256 colors
+	n := int(attribute & 0xff)
+	if profile == ANSI16 {
+		if n >= 16 {
+			r, g, b := ansi256ToRGB(n)
+			n = nearestANSI16(r, g, b)
+		}
+		return ansi16Code(n, background)
+	}
+
+	return prefix + "5;" + strconv.Itoa(n)
+}
+
+// ansi16Code will return the SGR code of one of the 16 basic colors.
+func ansi16Code(n int, background bool) string {
+	code := 30 + n
+	if n >= 8 {
+		code = 90 + n - 8
+	}
+	if background {
+		code += 10
+	}
+	return strconv.Itoa(code)
+}
+
+// rgbTo256 will return the index of the color in the 256 color palette that is nearest to the given RGB color.
+// Both the 6x6x6 color cube and the grayscale ramp are considered.
+func rgbTo256(r, g, b int) int {
+
+	// Find the nearest color in the cube
+	ri, gi, bi := nearestLevel(r), nearestLevel(g), nearestLevel(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDistance := distance(r, g, b, ansi256Levels[ri], ansi256Levels[gi], ansi256Levels[bi])
+
+	// Find the nearest gray
+	gray := ((r+g+b)/3 - 8 + 5) / 10
+	if gray < 0 {
+		gray = 0
+	} else if gray > 23 {
+		gray = 23
+	}
+	level := 8 + 10*gray
+	if distance(r, g, b, level, level, level) < cubeDistance {
+		return 232 + gray
+	}
+
+	return cube
+}
+
+// ansi256ToRGB will return the RGB value of a color in the 256 color palette.
+func ansi256ToRGB(n int) (r, g, b int) {
+	switch {
+	case n < 16:
+		return ansi16Palette[n][0], ansi16Palette[n][1], ansi16Palette[n][2]
+	case n < 232:
+		n -= 16
+		return ansi256Levels[n/36], ansi256Levels[n/6%6], ansi256Levels[n%6]
+	default:
+		level := 8 + 10*(n-232)
+		return level, level, level
+	}
+}
+
+// nearestANSI16 will return the index of the basic color that is nearest to the given RGB color.
+func nearestANSI16(r, g, b int) (nearest int) {
+	best := -1
+	for i, color := range ansi16Palette {
+		if d := distance(r, g, b, color[0], color[1], color[2]); best < 0 || d < best {
+			best, nearest = d, i
+		}
+	}
+	return
+}
+
+// nearestLevel will return the index of the step in the color cube that is nearest to the given intensity.
+func nearestLevel(v int) int {
+	switch {
+	case v < 48:
+		return 0
+	case v < 115:
+		return 1
+	default:
+		return (v - 35) / 40
+	}
+}
+
+// distance will return the squared distance between two RGB colors.
+func distance(r1, g1, b1, r2, g2, b2 int) int {
+	dr, dg, db := r1-r2, g1-g2, b1-b2
+	return dr*dr + dg*dg + db*db
+}
+
+// parseHex will parse a color in the format "#rrggbb" or "#rgb". The leading '#' is optional.
+func parseHex(hex string) (r, g, b uint8, ok bool) {
+
+	hex = strings.TrimPrefix(hex, "#")
+
+	// Expand the short format
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return 0, 0, 0, false
+	}
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return 0, 0, 0, false
+	}
+
+	return uint8(value >> 16), uint8(value >> 8), uint8(value), true
+}
@@ -0,0 +1,161 @@
+package plog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pd93/plog/formatters"
+)
+
+// The names of the basic colors, in SGR order.
+var colorNames = [8]string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// The names of the font decorations, in SGR order.
+var decorationNames = [9]string{"bold", "faint", "italic", "underline", "blink", "blink-rapid", "reverse", "concealed", "crossed-out"}
+
+// attributeNames maps each name to its attribute and is built from the lists above.
+var attributeNames = func() map[string]Attribute {
+
+	names := map[string]Attribute{
+		"reset":      Reset,
+		"no-reset":   NoReset,
+		"default":    FgDefault,
+		"bg-default": BgDefault,
+	}
+
+	// Add the decorations
+	for i, name := range decorationNames {
+		names[name] = Attribute(i) + Bold
+	}
+
+	// Add the colors
+	for i, name := range colorNames {
+		names[name] = Attribute(i) + FgBlack
+		names["hi-"+name] = Attribute(i) + FgHiBlack
+		names["bg-"+name] = Attribute(i) + BgBlack
+		names["bg-hi-"+name] = Attribute(i) + BgHiBlack
+	}
+
+	return names
+}()
+
+// ParseAttribute will return the attribute with the given name. Names are not case sensitive.
+// Valid names are:
+//   - Font decorations: 'bold', 'faint', 'italic', 'underline', 'blink', 'blink-rapid', 'reverse', 'concealed' and 'crossed-out'
+//   - Colors: 'black', 'red', 'green', 'yellow', 'blue', 'magenta', 'cyan', 'white' and 'default'
+//   - Hi-intensity colors: 'hi-' followed by a color (e.g. 'hi-red')
+//   - 256 colors: 'color-' followed by the color number (e.g. 'color-208')
+//   - 24-bit colors: a hex string (e.g. '#ff8800')
+//
+// Any color can be prefixed with 'bg-' to set the background color instead (e.g. 'bg-hi-red' or 'bg-#ff8800').
+func ParseAttribute(name string) (Attribute, error) {
+
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	// Named attributes
+	if attribute, ok := attributeNames[name]; ok {
+		return attribute, nil
+	}
+
+	// Extended colors can be set on the background
+	background := strings.HasPrefix(name, "bg-")
+	color := strings.TrimPrefix(name, "bg-")
+
+	switch {
+
+	// 256 colors
+	case strings.HasPrefix(color, "color-"):
+		n, err := strconv.ParseUint(strings.TrimPrefix(color, "color-"), 10, 8)
+		if err != nil {
+			break
+		}
+		if background {
+			return Bg256(uint8(n)), nil
+		}
+		return Fg256(uint8(n)), nil
+
+	// 24-bit colors
+	case strings.HasPrefix(color, "#"):
+		r, g, b, ok := parseHex(color)
+		if !ok {
+			break
+		}
+		if background {
+			return BgRGB(r, g, b), nil
+		}
+		return FgRGB(r, g, b), nil
+	}
+
+	return Reset, fmt.Errorf("Invalid attribute: '%s'", name)
+}
+
+// String will return the name of the attribute (See `ParseAttribute`).
+func (attribute Attribute) String() string {
+
+	// Extended colors
+	if attribute&extendedColor != 0 {
+		var prefix string
+		if attribute&backgroundColor != 0 {
+			prefix = "bg-"
+		}
+		if attribute&rgbColor != 0 {
+			return fmt.Sprintf("%s#%06x", prefix, int(attribute&0xffffff))
+		}
+		return fmt.Sprintf("%scolor-%d", prefix, int(attribute&0xff))
+	}
+
+	// Basic attributes
+	switch {
+	case attribute == Reset:
+		return "reset"
+	case attribute == NoReset:
+		return "no-reset"
+	case attribute == FgDefault:
+		return "default"
+	case attribute == BgDefault:
+		return "bg-default"
+	case attribute >= Bold && attribute <= CrossedOut:
+		return decorationNames[attribute-Bold]
+	case attribute >= FgBlack && attribute <= FgWhite:
+		return colorNames[attribute-FgBlack]
+	case attribute >= FgHiBlack && attribute <= FgHiWhite:
+		return "hi-" + colorNames[attribute-FgHiBlack]
+	case attribute >= BgBlack && attribute <= BgWhite:
+		return "bg-" + colorNames[attribute-BgBlack]
+	case attribute >= BgHiBlack && attribute <= BgHiWhite:
+		return "bg-hi-" + colorNames[attribute-BgHiBlack]
+	}
+
+	return strconv.Itoa(int(attribute))
+}
+
+// MarshalText will encode the attribute as its name.
+// This allows attributes to be written to JSON documents (e.g. themes) in a readable format.
+func (attribute Attribute) MarshalText() ([]byte, error) {
+	return []byte(attribute.String()), nil
+}
+
+// UnmarshalText will decode an attribute from its name (See `ParseAttribute`).
+func (attribute *Attribute) UnmarshalText(text []byte) (err error) {
+	*attribute, err = ParseAttribute(string(text))
+	return
+}
+
+// resolveMarkup will return the style for the name of a markup tag.
+// A tag can contain several attributes separated by commas (e.g. "<bold,red>").
+func resolveMarkup(name string) (formatters.Style, bool) {
+
+	var attributes []Attribute
+
+	// Loop through the attribute names and parse them
+	for _, attributeName := range strings.Split(name, ",") {
+		attribute, err := ParseAttribute(attributeName)
+		if err != nil || attribute == NoReset {
+			return "", false
+		}
+		attributes = append(attributes, attribute)
+	}
+
+	return newStyle(attributes), true
+}
@@ -1,7 +1,10 @@
 package plog
 
 import (
+	"bytes"
 	"testing"
+
+	"github.com/pd93/plog/mocks"
 )
 
 type colorTest struct {
@@ -16,6 +19,7 @@ func TestColor(t *testing.T) {
 		{"test", []Attribute{FgRed}, "\x1b[31mtest\x1b[0m"},
 		{"test", []Attribute{FgRed, Underline}, "\x1b[31;4mtest\x1b[0m"},
 		{"test", []Attribute{FgRed, FgBlue}, "\x1b[31;34mtest\x1b[0m"},
+		{"test", []Attribute{FgRed, NoReset}, "\x1b[31mtest"},
 	}
 
 	// Loop through the tests
@@ -25,8 +29,168 @@ func TestColor(t *testing.T) {
 		output := Color(test.message, test.attributes...)
 
 		// Check if the output is correct
-		if output != test.expected {
+		if string(output) != test.expected {
 			t.Errorf("[%d] Incorrect output. Expected '%s', received '%s'", i, test.expected, output)
 		}
 	}
 }
+
+type extendedColorTest struct {
+	profile    ColorProfile
+	attributes []Attribute
+	expected   string
+}
+
+func TestExtendedColor(t *testing.T) {
+
+	tests := []extendedColorTest{
+		{TrueColor, []Attribute{Fg256(208)}, "\x1b[38;5;208mtest\x1b[0m"},
+		{TrueColor, []Attribute{FgRGB(255, 136, 0), Bold}, "\x1b[38;2;255;136;0;1mtest\x1b[0m"},
+		{TrueColor, []Attribute{FgHex("#ff8800"), BgHex("f80")}, "\x1b[38;2;255;136;0;48;2;255;136;0mtest\x1b[0m"},
+		{TrueColor, []Attribute{FgHex("invalid"), BgHex("#12345")}, "\x1b[39;49mtest\x1b[0m"},
+		{TrueColor, []Attribute{Bg256(17), BgRGB(1, 2, 3)}, "\x1b[48;5;17;48;2;1;2;3mtest\x1b[0m"},
+		{ANSI256, []Attribute{FgHex("#ff8800")}, "\x1b[38;5;208mtest\x1b[0m"},
+		{ANSI256, []Attribute{FgRGB(128, 128, 128), BgRGB(0, 0, 0)}, "\x1b[38;5;244;48;5;16mtest\x1b[0m"},
+		{ANSI256, []Attribute{Fg256(208), FgRed}, "\x1b[38;5;208;31mtest\x1b[0m"},
+		{ANSI16, []Attribute{FgRGB(255, 0, 0), BgRGB(0, 0, 0)}, "\x1b[91;40mtest\x1b[0m"},
+		{ANSI16, []Attribute{Fg256(1), Fg256(12), Bg256(46)}, "\x1b[31;94;102mtest\x1b[0m"},
+	}
+
+	// Restore the color profile after the tests
+	defer SetColorProfile(CurrentColorProfile())
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Call the function
+		SetColorProfile(test.profile)
+		output := Color("test", test.attributes...)
+
+		// Check if the output is correct
+		if string(output) != test.expected {
+			t.Errorf("[%d] Incorrect output. Expected '%q', received '%q'", i, test.expected, output)
+		}
+
+		// Styles should be rendered the same way
+		if style := newStyle(test.attributes); style.Apply("test") != test.expected {
+			t.Errorf("[%d] Incorrect output. Expected '%q', received '%q'", i, test.expected, style.Apply("test"))
+		}
+	}
+}
+
+type colorProfileTest struct {
+	colorTerm string
+	term      string
+	expected  ColorProfile
+}
+
+func TestDetectColorProfile(t *testing.T) {
+
+	tests := []colorProfileTest{
+		{"", "", ANSI16},
+		{"", "xterm", ANSI16},
+		{"", "xterm-256color", ANSI256},
+		{"truecolor", "xterm-256color", TrueColor},
+		{"24bit", "", TrueColor},
+		{"", "xterm-direct", TrueColor},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		t.Setenv("COLORTERM", test.colorTerm)
+		t.Setenv("TERM", test.term)
+
+		if output := DetectColorProfile(); output != test.expected {
+			t.Errorf("[%d] Incorrect output. Expected '%d', received '%d'", i, test.expected, output)
+		}
+	}
+}
+
+type parseAttributeTest struct {
+	name     string
+	expected Attribute
+}
+
+func TestParseAttribute(t *testing.T) {
+
+	tests := []parseAttributeTest{
+		{"bold", Bold},
+		{"crossed-out", CrossedOut},
+		{"Red", FgRed},
+		{"hi-white", FgHiWhite},
+		{"bg-cyan", BgCyan},
+		{"bg-hi-black", BgHiBlack},
+		{"default", FgDefault},
+		{"color-208", Fg256(208)},
+		{"bg-color-17", Bg256(17)},
+		{"#ff8800", FgRGB(255, 136, 0)},
+		{"bg-#f80", BgRGB(255, 136, 0)},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Call the function
+		output, err := ParseAttribute(test.name)
+		if err != nil {
+			t.Error(err)
+		}
+
+		// Check if the output is correct
+		if output != test.expected {
+			t.Errorf("[%d] Incorrect output. Expected '%d', received '%d'", i, test.expected, output)
+		}
+
+		// The name of the attribute should parse to the same attribute
+		if roundTrip, err := ParseAttribute(output.String()); err != nil || roundTrip != output {
+			t.Errorf("[%d] Incorrect output. Expected '%s' to parse to '%d', received '%d'", i, output, output, roundTrip)
+		}
+	}
+
+	// Invalid names should return an error
+	for _, name := range []string{"", "purple", "color-256", "#12345", "bg-bold"} {
+		if _, err := ParseAttribute(name); err == nil {
+			t.Errorf("Expected an error for '%s'", name)
+		}
+	}
+}
+
+type styleTest struct {
+	attributes []Attribute
+	expected   string
+}
+
+func TestColorMapReset(t *testing.T) {
+
+	tests := []styleTest{
+		{[]Attribute{FgRed, NoReset}, "2006-01-02T15:04:05Z [\x1b[31mINFO\x1b[0m] Test string\n"},
+		{[]Attribute{NoReset}, "2006-01-02T15:04:05Z [INFO] Test string\n"},
+		{[]Attribute{FgRed, Reset}, "2006-01-02T15:04:05Z [INFO] Test string\n"},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Create a colored logger that uses the attributes for the info level
+		var buffer bytes.Buffer
+		logger := NewLogger(
+			WithOutput(&buffer),
+			WithColorLogging(true),
+			WithClock(mocks.Now),
+			WithLogLevelColorMap(NewLogLevelColorMap(WithLogLevelColorMapping(InfoLevel, test.attributes...))),
+		)
+
+		logger.Info("Test string")
+
+		// Check if the output is correct
+		if output := buffer.String(); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", i, test.expected, output)
+		}
+	}
+
+	// The Color function should still leave the color open
+	if output := Color("INFO", FgRed, NoReset); output != "\x1b[31mINFO" {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", "\x1b[31mINFO", output)
+	}
+}
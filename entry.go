@@ -0,0 +1,185 @@
+package plog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pd93/plog/formatters"
+)
+
+// Buffers that grow larger than this are not returned to the pool.
+const maxPooledBufferSize = 64 << 10
+
+// An entry holds the reusable record, render context and buffer needed to format a log.
+type entry struct {
+	log       Log // The log as it is passed to hooks and formatters, with its message rendered and redacted
+	record    formatters.Record
+	ctx       formatters.RenderContext
+	buffer    []byte
+	variables []interface{} // Holds rendered and redacted variables
+	tags      Tags          // Holds redacted tags
+	markup    []interface{} // Holds the message with its markup, if the logger renders markup
+	err       error         // Holds the first error passed to the logging function
+}
+
+// Entries are reused to avoid allocating when formatting each log.
+var entryPool = sync.Pool{
+	New: func() interface{} {
+		return &entry{
+			buffer: make([]byte, 0, 1024),
+		}
+	},
+}
+
+// newEntry will fetch an entry from the pool.
+func newEntry() *entry {
+	return entryPool.Get().(*entry)
+}
+
+// release will return the entry to the pool.
+// The variables are cleared so that the pool does not keep them alive.
+func (entry *entry) release() {
+
+	entry.log = Log{}
+	entry.record.Variables = nil
+	entry.record.Err = nil
+	entry.err = nil
+	entry.variables = clearVariables(entry.variables)
+	entry.markup = clearVariables(entry.markup)
+
+	// Don't keep hold of very large buffers
+	if cap(entry.buffer) > maxPooledBufferSize {
+		return
+	}
+
+	entryPool.Put(entry)
+}
+
+// prepare will copy the log into the entry, redacting its variables and tags and rendering any formatted message.
+// If markup is true, the formatted message is also kept with its markup (See `entry.variablesWithMarkup`).
+// The original log is never modified, since it may be shared with other loggers.
+func (entry *entry) prepare(log *Log, redactor *Redactor, markup bool) {
+
+	entry.log = *log
+	entry.markup = clearVariables(entry.markup)
+	entry.err = findError(log.variables, redactor)
+	if redactor == nil && !log.formatted {
+		return
+	}
+
+	// Redact the variables
+	entry.variables = clearVariables(entry.variables)
+	for _, variable := range log.variables {
+		if redactor != nil {
+			variable = redactor.Redact(variable)
+		}
+		entry.variables = append(entry.variables, variable)
+	}
+
+	// Render the message using the redacted variables. The message is redacted again in case the format completes a pattern
+	// Records always end with a newline, so any newline at the end of the format is removed
+	if log.formatted {
+		message := sprintf(log.format, entry.variables...)
+		if strings.HasSuffix(message, "\n") {
+			message = strings.TrimSuffix(message[:len(message)-1], "\r")
+		}
+		if redactor != nil {
+			message = redactor.RedactString(message)
+		}
+		if markup {
+			entry.markup = append(entry.markup, formatMessageMarkup(log.format, entry.variables, message, redactor))
+		}
+		entry.variables = append(clearVariables(entry.variables), message)
+	}
+	entry.log.variables = entry.variables
+
+	// Redact the tags
+	if redactor != nil {
+		entry.tags = entry.tags[:0]
+		for _, tag := range log.tags {
+			entry.tags = append(entry.tags, Tag(redactor.RedactString(string(tag))))
+		}
+		entry.log.tags = entry.tags
+	}
+}
+
+// sprintf will format a message in the same way as `fmt.Sprintf`.
+// Error functions accept the same formats as `fmt.Errorf`, so "%w" is formatted in the same way as "%v".
+func sprintf(format string, variables ...interface{}) string {
+	if strings.Contains(format, "%w") {
+		return fmt.Errorf(format, variables...).Error()
+	}
+	return fmt.Sprintf(format, variables...)
+}
+
+// findError will return the first error in the variables, so that formatters can write it in a structured form even if it is formatted into the message.
+// If the error contains anything that would be redacted, an error with the redacted message is returned instead.
+func findError(variables []interface{}, redactor *Redactor) error {
+
+	for _, variable := range variables {
+		err, ok := variable.(error)
+		if !ok || err == nil {
+			continue
+		}
+		if redactor == nil {
+			return err
+		}
+
+		// The message and details are printed with fmt, which doesn't panic if the error is a nil pointer
+		message, detail := fmt.Sprint(err), fmt.Sprintf("%+v", err)
+		if redacted := redactor.RedactString(message); redacted != message || redactor.RedactString(detail) != detail {
+			return errors.New(redacted)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// variablesWithMarkup will return the variables that are passed to the formatter.
+// These are the same as the log's variables, except that a formatted message keeps its markup separate from its values.
+func (entry *entry) variablesWithMarkup() []interface{} {
+	if len(entry.markup) > 0 {
+		return entry.markup
+	}
+	return entry.log.variables
+}
+
+// formatMessageMarkup will format a message so that markup is only read from the format string.
+// Each part is redacted separately. If this doesn't match the redacted message (e.g. a pattern spans a value), the whole message is written literally.
+func formatMessageMarkup(format string, variables []interface{}, message string, redactor *Redactor) formatters.Markup {
+
+	// Remove the newline at the end of the message, which may come from the format string or a value
+	markup := formatMarkup(format, variables)
+	for i := len(markup) - 1; i >= 0; i-- {
+		if markup[i] == "" {
+			continue
+		}
+		if strings.HasSuffix(markup[i], "\n") {
+			markup[i] = strings.TrimSuffix(markup[i][:len(markup[i])-1], "\r")
+		}
+		break
+	}
+	if redactor != nil {
+		for i, part := range markup {
+			markup[i] = redactor.RedactString(part)
+		}
+	}
+
+	if markup.String() != message {
+		return formatters.Markup{"", message}
+	}
+
+	return markup
+}
+
+// clearVariables will empty the given slice so that it can be reused.
+// The variables are cleared so that the pool does not keep them alive.
+func clearVariables(variables []interface{}) []interface{} {
+	for i := range variables {
+		variables[i] = nil
+	}
+	return variables[:0]
+}
@@ -20,8 +20,10 @@ func main() {
 // If PLogs default formatters aren't quite right for your project, you can provide your own.
 // You can do this during logger creation (`log.NewLogger()`) or using the `logger.Options()` method.
 // Either way, you do this by providing the `plog.WithFormatter()` functional option as an argument.
-// All formatters must take a timestamp, log level, a list of variables and a list of tags as arguments.
+// The formatter is a function which takes a timestamp, log level, a list of variables and a list of tags as arguments.
 // It is up to you to decide how or if you display this data.
+// For full control, implement the `log.RecordFormatter` interface and use `plog.WithRecordFormatter()` instead.
+// Record formatters receive the structured record and a render context with the logger's settings.
 func FormatterExample() (err error) {
 
 	// Create a logger
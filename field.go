@@ -0,0 +1,19 @@
+package plog
+
+import "github.com/pd93/plog/formatters"
+
+// A Field is a key/value pair that can be passed to any logging function alongside the other variables.
+// Formatters can choose to display fields separately from the rest of the message.
+type Field = formatters.Field
+
+//
+// Constructors
+//
+
+// NewField creates and returns a field with the given key and value.
+func NewField(key string, value interface{}) Field {
+	return Field{
+		Key:   key,
+		Value: value,
+	}
+}
@@ -2,6 +2,8 @@ package plog
 
 import (
 	"os"
+	"sync"
+	"sync/atomic"
 
 	"github.com/pd93/plog/writers"
 )
@@ -17,6 +19,8 @@ type File struct {
 	writer      Writer    // The writer we should use to write the text to the output
 	sequencer   Sequencer // Used to determine how the filename should change when rotating
 	maxFileSize int64
+	mutex       sync.Mutex
+	counters    *fileCounters
 }
 
 // A FileOption is a function that sets an option on a given file.
@@ -36,6 +40,7 @@ func NewFile(format string, opts ...FileOption) (file *File, err error) {
 		writer:      writers.Text,
 		sequencer:   nil,
 		maxFileSize: -1,
+		counters:    &fileCounters{},
 	}
 
 	// Apply the custom options
@@ -175,6 +180,17 @@ func (file *File) MaxFileSize() int64 {
 // Write will write bytes to the file.
 func (file *File) Write(p []byte) (n int, err error) {
 
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+
+	// Count the bytes written and any errors
+	defer func() {
+		atomic.AddUint64(&file.counters.bytes, uint64(n))
+		if err != nil {
+			atomic.AddUint64(&file.counters.errors, 1)
+		}
+	}()
+
 	// Check if we have met any of the rotation conditions
 	shouldRotate, err := file.ShouldRotate(p)
 	if err != nil {
@@ -184,7 +200,7 @@ func (file *File) Write(p []byte) (n int, err error) {
 	if shouldRotate {
 
 		// Rotate the file
-		if err = file.Rotate(); err != nil {
+		if err = file.rotate(); err != nil {
 			return
 		}
 	}
@@ -196,6 +212,19 @@ func (file *File) Write(p []byte) (n int, err error) {
 // Rotate will close the old file and open a new one with the next name in the sequence.
 func (file *File) Rotate() (err error) {
 
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+
+	if err = file.rotate(); err != nil {
+		atomic.AddUint64(&file.counters.errors, 1)
+	}
+
+	return
+}
+
+// rotate will close the old file and open a new one with the next name in the sequence.
+func (file *File) rotate() (err error) {
+
 	// Name of the file we're going to rotate to
 	var fileName string
 	var prevFileName string
@@ -231,6 +260,11 @@ func (file *File) Rotate() (err error) {
 		return
 	}
 
+	// Only count rotations away from a previous file
+	if prevFileName != "" {
+		atomic.AddUint64(&file.counters.rotations, 1)
+	}
+
 	return
 }
 
@@ -0,0 +1,61 @@
+package plog
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pd93/plog/mocks"
+	"github.com/pd93/plog/sequencers"
+)
+
+func TestFileDateTimeClock(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "plog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create a file that rotates after every message and is named using a fake clock
+	clock := mocks.NewClock(mocks.Now())
+	file, err := NewFile(filepath.Join(dir, "log-%s.txt"),
+		WithSequencer(sequencers.NewDateTime(clock.Now)),
+		WithMaxFileSize(1),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	// Write a message every hour
+	for i := 0; i < 3; i++ {
+		if _, err := file.Write([]byte("Test string\n")); err != nil {
+			t.Fatal(err)
+		}
+		clock.Advance(time.Hour)
+	}
+
+	// Expected output
+	expected := []string{
+		"log-2006-01-02T15:04:05.000Z.txt",
+		"log-2006-01-02T16:04:05.000Z.txt",
+		"log-2006-01-02T17:04:05.000Z.txt",
+	}
+
+	// Check if the correct files were created
+	matches, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != len(expected) {
+		t.Fatalf("Expected %d files, received %d: %v", len(expected), len(matches), matches)
+	}
+	for i, match := range matches {
+		if filepath.Base(match) != expected[i] {
+			t.Errorf("[%d] Expected: '%s'\nReceived: '%s'", i, expected[i], filepath.Base(match))
+		}
+	}
+}
@@ -1,4 +1,66 @@
 package plog
 
+import (
+	"github.com/pd93/plog/formatters"
+)
+
 // A Formatter is a function that generates a formatted string from a log.
+// The timestamp, log level and tags are pre-rendered according to the logger's settings (e.g. its time zone, encoding and colors).
+// PLog includes several formatters for convenience (See `formatters` subpackage).
 type Formatter func(timestamp, logLevel string, variables []interface{}, tags []string) (string, error)
+
+// A RecordFormatter generates the output for a log from its structured record.
+// Format appends the formatted record to dst and returns the extended buffer.
+// The render context holds the logger settings that the formatter may need, such as color styles and timestamp settings.
+// Every formatter in the `formatters` subpackage has a record formatter (e.g. `formatters.TextRecord`).
+type RecordFormatter interface {
+	Format(dst []byte, record *formatters.Record, ctx *formatters.RenderContext) ([]byte, error)
+}
+
+// Format will render the timestamp, log level and tags and call the formatter function.
+// This allows a Formatter to be used wherever a RecordFormatter is expected.
+func (formatter Formatter) Format(dst []byte, record *formatters.Record, ctx *formatters.RenderContext) ([]byte, error) {
+
+	tags := make([]string, len(record.Tags))
+
+	// Loop through the tags and color them
+	for i, tag := range record.Tags {
+		tags[i] = ctx.TagStyle(i).Apply(tag)
+	}
+
+	output, err := formatter(record.Timestamp.String(), ctx.LogLevelStyle.Apply(record.LogLevel), record.Variables, tags)
+	if err != nil {
+		return dst, err
+	}
+
+	return append(dst, output...), nil
+}
+
+// colorMapStyles holds a logger's color maps rendered as styles so that they can be passed to formatters.
+// The styles depend on the color profile, so they are rebuilt if the profile changes.
+type colorMapStyles struct {
+	built     bool
+	profile   ColorProfile
+	logLevels map[int]formatters.Style
+	tags      map[string]formatters.Style
+}
+
+// newColorMapStyles will render the styles of each log level and tag in the given color maps.
+func newColorMapStyles(profile ColorProfile, logLevelColorMap LogLevelColorMap, tagColorMap TagColorMap) colorMapStyles {
+
+	styles := colorMapStyles{
+		built:     true,
+		profile:   profile,
+		logLevels: make(map[int]formatters.Style, len(logLevelColorMap)),
+		tags:      make(map[string]formatters.Style, len(tagColorMap)),
+	}
+
+	for logLevel, attributes := range logLevelColorMap {
+		styles.logLevels[int(logLevel)] = newStyle(attributes)
+	}
+	for tag, attributes := range tagColorMap {
+		styles.tags[string(tag)] = newStyle(attributes)
+	}
+
+	return styles
+}
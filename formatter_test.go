@@ -0,0 +1,139 @@
+package plog
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pd93/plog/formatters"
+	"github.com/pd93/plog/mocks"
+)
+
+func TestFormatter(t *testing.T) {
+
+	// Expected output
+	const expected = "2006-01-02T15:04:05Z - \x1b[32mINFO\x1b[0m - [\x1b[31mtag1\x1b[0m \x1b[37;2mtag2\x1b[0m] - Test string\n"
+
+	// Create a logger with a simple formatting function
+	var buffer bytes.Buffer
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithColorLogging(true),
+		WithClock(mocks.Now),
+		WithTagColorMap(NewTagColorMap(WithTagColorMapping("tag1", FgRed))),
+		WithFormatter(func(timestamp, logLevel string, variables []interface{}, tags []string) (string, error) {
+			return fmt.Sprintf("%s - %s - [%s] - %v", timestamp, logLevel, strings.Join(tags, " "), variables[0]), nil
+		}),
+	)
+
+	logger.TInfo(Tags{"tag1", "tag2"}, "Test string")
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expected, output)
+	}
+}
+
+type bundledFormatterTest struct {
+	opt      LoggerOption
+	expected string
+}
+
+func TestFormatterBundled(t *testing.T) {
+
+	tests := []bundledFormatterTest{
+		{
+			WithFormatter(formatters.JSON),
+			`{"timestamp":"1136214245","logLevel":"INFO","variables":["Test string"],"tags":["tag1"]}` + "\n",
+		},
+		{
+			WithRecordFormatter(formatters.JSONRecord),
+			`{"timestamp":1136214245,"logLevel":"INFO","variables":["Test string"],"tags":["tag1"]}` + "\n",
+		},
+		{
+			WithFormatter(formatters.Text),
+			"1136214245 [\x1b[32mINFO\x1b[0m] [\x1b[31m#tag1\x1b[0m] Test string\n",
+		},
+		{
+			WithRecordFormatter(formatters.TextRecord),
+			"1136214245 [\x1b[32mINFO\x1b[0m] [\x1b[31m#tag1\x1b[0m] Test string\n",
+		},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Create a colored logger using one of the bundled formatters
+		var buffer bytes.Buffer
+		logger := NewLogger(
+			WithOutput(&buffer),
+			WithColorLogging(true),
+			WithClock(mocks.Now),
+			WithTimestampEncoding(formatters.UnixSeconds),
+			WithLogLevelColorMap(NewLogLevelColorMap(WithLogLevelColorMapping(InfoLevel, FgGreen))),
+			WithTagColorMap(NewTagColorMap(WithTagColorMapping("tag1", FgRed))),
+			test.opt,
+		)
+
+		// Formatting functions receive strings, so only the record formatters write the timestamp as a number
+		logger.TInfo(Tags{"tag1"}, "Test string")
+		if output := buffer.String(); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", i, test.expected, output)
+		}
+	}
+}
+
+func TestFormatterGetters(t *testing.T) {
+
+	// The getter should return the formatting function that was set
+	logger := NewLogger(WithFormatter(formatters.JSON))
+	if logger.Formatter() == nil {
+		t.Error("Expected the formatter to be set")
+	}
+
+	// Setting a record formatter should remove the formatting function
+	logger.Options(WithRecordFormatter(formatters.Logfmt))
+	if logger.Formatter() != nil {
+		t.Error("Expected the formatter to be removed")
+	}
+	if logger.RecordFormatter() == nil {
+		t.Error("Expected the record formatter to be set")
+	}
+}
+
+func TestRecordFormatterContext(t *testing.T) {
+
+	// Create a logger with a record formatter that keeps the render context
+	var ctx formatters.RenderContext
+	location := time.FixedZone("EST", -5*60*60)
+	logger := NewLogger(
+		WithOutput(ioutil.Discard),
+		WithColorLogging(true),
+		WithTimestampFormat(time.Kitchen),
+		WithTimestampLocation(location),
+		WithTimestampPrecision(time.Second),
+		WithTimestampEncoding(formatters.UnixMillis),
+		WithLogLevelColorMap(NewLogLevelColorMap(WithLogLevelColorMapping(InfoLevel, FgGreen))),
+		WithTagColorMap(NewTagColorMap(WithTagColorMapping("tag1", FgRed))),
+		WithRecordFormatter(formatters.Func(func(dst []byte, record *formatters.Record, c *formatters.RenderContext) ([]byte, error) {
+			ctx = *c
+			return dst, nil
+		})),
+	)
+
+	logger.Info("Test string")
+
+	// Check if the context holds the logger's settings
+	if ctx.TimestampFormat != time.Kitchen || ctx.TimestampLocation != location || ctx.TimestampPrecision != time.Second || ctx.TimestampEncoding != formatters.UnixMillis {
+		t.Errorf("Incorrect timestamp settings: '%s', '%v', '%s', '%d'", ctx.TimestampFormat, ctx.TimestampLocation, ctx.TimestampPrecision, ctx.TimestampEncoding)
+	}
+	if style := ctx.LogLevelColorMap[int(InfoLevel)]; style != "\x1b[32m" {
+		t.Errorf("Incorrect log level style: '%q'", style)
+	}
+	if style := ctx.TagColorMap["tag1"]; style != "\x1b[31m" {
+		t.Errorf("Incorrect tag style: '%q'", style)
+	}
+}
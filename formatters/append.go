@@ -0,0 +1,283 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Hexadecimal digits used when escaping JSON strings.
+const hex = "0123456789abcdef"
+
+// appendVariables will append each variable to dst, separated by spaces.
+func appendVariables(dst []byte, variables []interface{}) []byte {
+
+	// Loop through the variables and format them
+	for i, variable := range variables {
+		if i > 0 {
+			dst = append(dst, ' ')
+		}
+		dst = appendValue(dst, variable)
+	}
+
+	return dst
+}
+
+// appendMessage will append the message of a record to dst.
+// If the log was written with a template, the template is rendered. Otherwise, any variables that are not fields are rendered.
+func appendMessage(dst []byte, record *Record, s sanitizer) []byte {
+
+	if record.Template != "" {
+		dst, _ = appendTemplate(dst, record.Template, record.Variables, s)
+		return dst
+	}
+
+	first := true
+	for _, variable := range record.Variables {
+		if _, ok := asField(variable); ok {
+			continue
+		}
+		if !first {
+			dst = append(dst, ' ')
+		}
+		first = false
+		if message, ok := variable.(Markup); ok {
+			dst = s.appendMarkup(dst, message)
+		} else {
+			dst = s.appendValue(dst, variable)
+		}
+	}
+
+	return dst
+}
+
+// appendValue will append a variable to dst in the same format as `fmt.Sprintf("%v", value)`.
+// Common types are appended directly to avoid the allocations made by the fmt package.
+func appendValue(dst []byte, value interface{}) []byte {
+	switch v := value.(type) {
+	case string:
+		return append(dst, v...)
+	case Colored:
+		return append(dst, v...)
+	case Markup:
+		for _, part := range v {
+			dst = append(dst, part...)
+		}
+		return dst
+	case bool:
+		return strconv.AppendBool(dst, v)
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int8:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int16:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(dst, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(dst, v, 10)
+	case uint:
+		return strconv.AppendUint(dst, uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(dst, uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(dst, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(dst, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(dst, v, 10)
+	case float32:
+		return strconv.AppendFloat(dst, float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.AppendFloat(dst, v, 'g', -1, 64)
+	case Field:
+		dst = append(dst, v.Key...)
+		dst = append(dst, '=')
+		return appendValue(dst, v.Value)
+	case Metric:
+		dst = append(dst, v.Name...)
+		dst = append(dst, '=')
+		return strconv.AppendFloat(dst, v.Value, 'g', -1, 64)
+	default:
+		return append(dst, fmt.Sprintf("%v", value)...)
+	}
+}
+
+// appendJSONValue will append a variable to dst as JSON in the same format as `json.Marshal(value)`.
+// Common types are appended directly to avoid the allocations made by the json package.
+func appendJSONValue(dst []byte, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(dst, "null"...), nil
+	case string:
+		return appendJSONString(dst, v), nil
+	case bool:
+		return strconv.AppendBool(dst, v), nil
+	case int:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int8:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int16:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int32:
+		return strconv.AppendInt(dst, int64(v), 10), nil
+	case int64:
+		return strconv.AppendInt(dst, v, 10), nil
+	case uint:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint8:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint16:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint32:
+		return strconv.AppendUint(dst, uint64(v), 10), nil
+	case uint64:
+		return strconv.AppendUint(dst, v, 10), nil
+	case float32:
+		return appendJSONFloat(dst, float64(v), 32)
+	case float64:
+		return appendJSONFloat(dst, v, 64)
+	case Field:
+		dst = append(dst, `{"key":`...)
+		dst = appendJSONString(dst, v.Key)
+		dst = append(dst, `,"value":`...)
+		dst, err := appendJSONValue(dst, v.Value)
+		return append(dst, '}'), err
+	default:
+		b, err := json.Marshal(value)
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, b...), nil
+	}
+}
+
+// appendJSONField will append a field to dst as a key of the current JSON object, preceded by a comma.
+// Errors and values that cannot be encoded as JSON are written as strings.
+func appendJSONField(dst []byte, field Field) []byte {
+
+	dst = append(dst, ',')
+	dst = appendJSONString(dst, field.Key)
+	dst = append(dst, ':')
+
+	if err, ok := field.Value.(error); ok && !isNilError(err) {
+		return appendJSONString(dst, err.Error())
+	}
+
+	n := len(dst)
+	b, err := appendJSONValue(dst, field.Value)
+	if err != nil {
+		return appendJSONString(b[:n], string(appendValue(nil, field.Value)))
+	}
+
+	return b
+}
+
+// isNilError will return whether or not an error is nil.
+// This includes non-nil errors that hold a nil pointer, which would panic if their Error() method was called.
+func isNilError(err error) bool {
+
+	if err == nil {
+		return true
+	}
+
+	value := reflect.ValueOf(err)
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
+// appendJSONFloat will append a float to dst in the same format as `json.Marshal()`.
+func appendJSONFloat(dst []byte, f float64, bits int) ([]byte, error) {
+
+	// JSON does not support these values
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return dst, &json.UnsupportedValueError{Str: strconv.FormatFloat(f, 'g', -1, bits)}
+	}
+
+	// Use exponent notation for very large or very small numbers
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+
+	n := len(dst)
+	dst = strconv.AppendFloat(dst, f, format, -1, bits)
+
+	// Clean up e-09 to e-9
+	if format == 'e' {
+		if len(dst)-n >= 4 && dst[len(dst)-4] == 'e' && dst[len(dst)-3] == '-' && dst[len(dst)-2] == '0' {
+			dst[len(dst)-2] = dst[len(dst)-1]
+			dst = dst[:len(dst)-1]
+		}
+	}
+
+	return dst, nil
+}
+
+// appendJSONString will append a quoted and escaped string to dst in the same format as `json.Marshal()`.
+func appendJSONString(dst []byte, s string) []byte {
+
+	dst = append(dst, '"')
+	start := 0
+
+	for i := 0; i < len(s); {
+
+		// Handle single byte characters
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' && b != '<' && b != '>' && b != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '"', '\\':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		// Handle multi-byte characters
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
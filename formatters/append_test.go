@@ -0,0 +1,94 @@
+package formatters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// Variables that are appended differently depending on their type
+var appendTestValues = []interface{}{
+	nil,
+	"Test string",
+	"Special \"characters\" \\ <html> & \n\r\t\b\f\x00\x1f \u2028 \u2029 \xff ünïcödé",
+	true,
+	int(-123), int8(-8), int16(-16), int32(-32), int64(-64),
+	uint(123), uint8(8), uint16(16), uint32(32), uint64(64),
+	float32(3.14159), float64(4.5), 1e21, 1e-7, -2.5e-10, 0.0, 100.0,
+	[]string{"Random", "string", "array"},
+	map[string]int{"a": 1},
+	errors.New("Test error"),
+	time.Second,
+	Metric{Name: "latency", Value: 12.5, Unit: "Milliseconds"},
+}
+
+func TestAppendValue(t *testing.T) {
+
+	// Loop through the tests
+	for i, value := range appendTestValues {
+
+		// The output should match the fmt package
+		expected := fmt.Sprintf("%v", value)
+		if output := string(appendValue(nil, value)); output != expected {
+			t.Errorf("[%d] Incorrect output. Expected '%s', received '%s'", i, expected, output)
+		}
+	}
+
+	// Fields should be appended as key=value
+	if output := string(appendValue(nil, Field{"key", 123})); output != "key=123" {
+		t.Errorf("Incorrect output. Expected 'key=123', received '%s'", output)
+	}
+}
+
+func TestAppendJSONValue(t *testing.T) {
+
+	// Loop through the tests
+	for i, value := range append(appendTestValues, Field{"key", "value"}) {
+
+		// The output should match the json package
+		b, err := json.Marshal(value)
+		if err != nil {
+			t.Error(err)
+		}
+		output, err := appendJSONValue(nil, value)
+		if err != nil {
+			t.Error(err)
+		}
+		if string(output) != string(b) {
+			t.Errorf("[%d] Incorrect output. Expected '%s', received '%s'", i, string(b), string(output))
+		}
+	}
+
+	// Values that are not supported by JSON should return an error
+	if _, err := appendJSONValue(nil, math.NaN()); err == nil {
+		t.Error("Expected an error when appending NaN")
+	}
+}
+
+type appendJSONFieldTest struct {
+	field    Field
+	expected string
+}
+
+func TestAppendJSONField(t *testing.T) {
+
+	tests := []appendJSONFieldTest{
+		{Field{"key", "value"}, `,"key":"value"`},
+		{Field{"error", errors.New("timeout")}, `,"error":"timeout"`},
+		{Field{"error", &pointerError{"timeout"}}, `,"error":"timeout"`},
+		{Field{"error", (*pointerError)(nil)}, `,"error":null`},
+		{Field{"ratio", math.NaN()}, `,"ratio":"NaN"`},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Check if the output is correct
+		if output := string(appendJSONField(nil, test.field)); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, test.expected, output)
+		}
+	}
+}
@@ -1,19 +1,47 @@
 package formatters
 
-import (
-	"fmt"
-	"strings"
-)
-
 // CSV will format a log into a comma-separated value (CSV) string.
+// Loggers use CSVRecord instead, which receives the raw timestamp (See `plog.WithFormatter`).
 func CSV(timestamp, logLevel string, variables []interface{}, tags []string) (string, error) {
+	return formatStrings(csv, timestamp, logLevel, variables, tags)
+}
+
+// CSVRecord will format a record into a comma-separated value (CSV) string.
+var CSVRecord = Func(csv)
+
+// csv will format a log into a comma-separated value (CSV) string.
+// The log level and tags are never colored.
+// Control characters are always escaped (including in tags) so that each log is written on a single line.
+func csv(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+
+	s := newSanitizer(ctx, false)
+	s.enabled = true
+	s.colors = false
+	s.markup = newMarkup(ctx, false, "")
+
+	dst = record.Timestamp.Append(dst)
+	dst = append(dst, ',')
+	dst = append(dst, record.LogLevel...)
+	dst = append(dst, ',')
+
+	// If the log has a template, keep the template and render the values as fields
+	if record.Template != "" {
+		dst = s.appendString(dst, record.Template)
+		if len(record.Variables) > 0 {
+			dst = append(dst, ' ')
+		}
+	}
 
-	strVariables := make([]string, len(variables))
+	dst = s.appendVariables(dst, record.Variables)
+	dst = append(dst, ',')
 
-	// Loop through the variables and format them
-	for i, variable := range variables {
-		strVariables[i] = fmt.Sprintf("%v", variable)
+	// Loop through the tags and separate them with colons
+	for i, tag := range record.Tags {
+		if i > 0 {
+			dst = append(dst, ':')
+		}
+		dst = s.appendString(dst, tag)
 	}
 
-	return fmt.Sprintf(`%s,%s,%s,%s`, timestamp, logLevel, strings.Join(strVariables, " "), strings.Join(tags, ":")), nil
+	return dst, nil
 }
@@ -11,26 +11,49 @@ func TestCSV(t *testing.T) {
 	const expected = `2006-01-02T15:04:05Z,INFO,Test string 123 4.5 true,tag1:tag2`
 
 	// Test log
-	var (
-		logLevel  = "INFO"
-		variables = []interface{}{"Test string", 123, 4.5, true}
-		timestamp = time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC).Format(time.RFC3339)
-		tags      = []string{"tag1", "tag2"}
-	)
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Variables: []interface{}{"Test string", 123, 4.5, true},
+		Tags:      []string{"tag1", "tag2"},
+	}
+
+	// Call the function
+	b, err := CSVRecord.Format(nil, record, &RenderContext{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if output := string(b); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestCSVTemplate(t *testing.T) {
+
+	// Expected output
+	const expected = `2006-01-02T15:04:05Z,INFO,user {user} logged in from {ip} user=alice ip=10.0.0.1 extra,tag1`
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Template:  "user {user} logged in from {ip}",
+		Variables: []interface{}{Field{"user", "alice"}, Field{"ip", "10.0.0.1"}, "extra"},
+		Tags:      []string{"tag1"},
+	}
 
 	// Call the function
-	output, err := CSV(
-		timestamp,
-		logLevel,
-		variables,
-		tags,
-	)
+	b, err := CSVRecord.Format(nil, record, &RenderContext{})
 	if err != nil {
 		t.Error(err)
 	}
 
 	// Check if the output is correct
-	if output != expected {
+	if output := string(b); output != expected {
 		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
 	}
 }
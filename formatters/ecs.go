@@ -0,0 +1,226 @@
+package formatters
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ECSVersion is the version of the Elastic Common Schema (ECS) that `ECS` formatters write.
+const ECSVersion = "8.11.0"
+
+// The keys that are always written by ECS formatters.
+var ecsKeys = []string{"@timestamp", "log.level", "message", "ecs.version", "tags", "log.origin.file.name", "log.origin.file.line", "log.origin.function"}
+
+// The keys that are written for errors.
+var ecsErrorKeys = []string{"error.message", "error.type", "error.stack_trace"}
+
+// ECS will format a log into an Elastic Common Schema (ECS) JSON document.
+// Use `NewECS` to add service metadata to every log.
+var ECS = NewECS()
+
+//
+// Structures
+//
+
+// ecs holds the metadata that is added to every log by an ECS formatter.
+type ecs struct {
+	service []Field
+	fields  []Field
+}
+
+// An ECSOption is a function that sets an option on an ECS formatter.
+type ECSOption func(e *ecs)
+
+//
+// Constructors
+//
+
+// NewECS will create a formatter that formats logs into Elastic Common Schema (ECS) JSON documents.
+// The timestamp, log level, message, tags and ECS version are always written. Fields are written as top-level keys,
+// so fields named after ECS fields (e.g. "user.name" or "trace.id") are mapped onto the schema.
+// The error passed to the logging function (See `Record.Err`) is written as `error.message`, `error.type` and `error.stack_trace`.
+// Otherwise, an error held by a field named "error" is written in the same way instead of as a field.
+// Fields that would clash with the keys written by the formatter (e.g. "message", or "log" which would clash with `log.level`) are written under `labels` instead (e.g. `labels.log`).
+// Any other field named "error" is also written under `labels`, since ECS uses it for the error object.
+// If the logger records callers (See `plog.WithCaller`), the location is written as `log.origin.*`.
+func NewECS(opts ...ECSOption) Func {
+
+	e := &ecs{}
+
+	// Apply the custom options
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e.format
+}
+
+//
+// Functional Options
+//
+
+// WithServiceName will return a function that sets the `service.name` of every log.
+func WithServiceName(name string) ECSOption {
+	return func(e *ecs) {
+		e.setService("service.name", name)
+	}
+}
+
+// WithServiceVersion will return a function that sets the `service.version` of every log.
+func WithServiceVersion(version string) ECSOption {
+	return func(e *ecs) {
+		e.setService("service.version", version)
+	}
+}
+
+// WithServiceEnvironment will return a function that sets the `service.environment` of every log (e.g. "production").
+func WithServiceEnvironment(environment string) ECSOption {
+	return func(e *ecs) {
+		e.setService("service.environment", environment)
+	}
+}
+
+// WithECSFields will return a function that adds the given fields to every log (e.g. `Field{"host.name", "host1"}`).
+func WithECSFields(fields ...Field) ECSOption {
+	return func(e *ecs) {
+		e.fields = append(e.fields, fields...)
+	}
+}
+
+//
+// Instance methods
+//
+
+// setService will set a service field, replacing any previous value.
+func (e *ecs) setService(key, value string) {
+	for i := range e.service {
+		if e.service[i].Key == key {
+			e.service[i].Value = value
+			return
+		}
+	}
+	e.service = append(e.service, Field{Key: key, Value: value})
+}
+
+// format will format a log into an ECS JSON document.
+// The log level and tags are never colored.
+func (e *ecs) format(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+
+	// Add the timestamp, log level and message. Markup is always removed
+	dst = append(dst, `{"@timestamp":"`...)
+	dst = record.Timestamp.Time.UTC().AppendFormat(dst, "2006-01-02T15:04:05.000Z07:00")
+	dst = append(dst, `","log.level":"`...)
+	for i := 0; i < len(record.LogLevel); i++ {
+		c := record.LogLevel[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		dst = append(dst, c)
+	}
+	dst = append(dst, `","message":`...)
+	message := appendMessage(nil, record, sanitizer{markup: newMarkup(ctx, false, "")})
+	dst = appendJSONString(dst, string(message))
+	dst = append(dst, `,"ecs.version":"`+ECSVersion+`"`...)
+
+	// If there are tags, add them to the output
+	if len(record.Tags) > 0 {
+		dst = append(dst, `,"tags":[`...)
+		for i, tag := range record.Tags {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, tag)
+		}
+		dst = append(dst, ']')
+	}
+
+	// Add the location of the code that wrote the log
+	if record.Caller.File != "" {
+		dst = append(dst, `,"log.origin.file.name":`...)
+		dst = appendJSONString(dst, filepath.Base(record.Caller.File))
+		dst = append(dst, `,"log.origin.file.line":`...)
+		dst = strconv.AppendInt(dst, int64(record.Caller.Line), 10)
+		dst = append(dst, `,"log.origin.function":`...)
+		dst = appendJSONString(dst, record.Caller.Function)
+	}
+
+	// Add the error passed to the logging function. Otherwise, use the first error variable or "error" field
+	err, merged := record.Err, -1
+	if isNilError(err) {
+		for i, variable := range record.Variables {
+			if field, ok := asField(variable); ok {
+				if field.Key != "error" {
+					continue
+				}
+				variable = field.Value
+			}
+			if variableErr, ok := variable.(error); ok && !isNilError(variableErr) {
+				err, merged = variableErr, i
+				break
+			}
+		}
+	}
+	keys := append(make([]string, 0, 16), ecsKeys...)
+	if !isNilError(err) {
+		dst = appendECSError(dst, err)
+		keys = append(keys, ecsErrorKeys...)
+	}
+
+	// Add the service metadata and static fields
+	for _, field := range e.service {
+		dst = appendJSONField(dst, field)
+		keys = append(keys, field.Key)
+	}
+	for _, field := range e.fields {
+		dst = appendECSField(dst, field, keys)
+	}
+
+	// Add the log's fields. An "error" field that was written as the error is not written again
+	for i, variable := range record.Variables {
+		if field, ok := asField(variable); ok && i != merged {
+			dst = appendECSField(dst, field, keys)
+		}
+	}
+
+	return append(dst, '}'), nil
+}
+
+// appendECSField will append a field to dst.
+// If the key clashes with one of the given keys, the field is written under `labels` instead (e.g. `labels.log`).
+// The key "error" always clashes, since ECS documents use it for the error object.
+func appendECSField(dst []byte, field Field, keys []string) []byte {
+
+	if field.Key == "error" {
+		field.Key = "labels.error"
+		return appendJSONField(dst, field)
+	}
+
+	// Keys clash if they are the same, or if one is an object that holds the other (e.g. "log" and "log.level")
+	for _, key := range keys {
+		if field.Key == key || strings.HasPrefix(key, field.Key+".") || strings.HasPrefix(field.Key, key+".") {
+			field.Key = "labels." + field.Key
+			break
+		}
+	}
+
+	return appendJSONField(dst, field)
+}
+
+// appendECSError will append the message, type and stack trace of an error to dst.
+// The stack trace is only written if the error prints more detail with `%+v` (e.g. errors from github.com/pkg/errors).
+func appendECSError(dst []byte, err error) []byte {
+
+	message := err.Error()
+	dst = append(dst, `,"error.message":`...)
+	dst = appendJSONString(dst, message)
+	dst = append(dst, `,"error.type":`...)
+	dst = appendJSONString(dst, fmt.Sprintf("%T", err))
+	if detail := fmt.Sprintf("%+v", err); detail != message {
+		dst = append(dst, `,"error.stack_trace":`...)
+		dst = appendJSONString(dst, detail)
+	}
+
+	return dst
+}
@@ -0,0 +1,89 @@
+package formatters
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// stackError is an error that prints a stack trace with `%+v`.
+type stackError struct{}
+
+func (err stackError) Error() string { return "failed" }
+
+func (err stackError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprint(s, "failed\nmain.run\n\tmain.go:12")
+		return
+	}
+	fmt.Fprint(s, "failed")
+}
+
+// pointerError is an error with a pointer receiver that panics if it is nil.
+type pointerError struct {
+	message string
+}
+
+func (err *pointerError) Error() string { return err.message }
+
+type ecsTest struct {
+	formatter Func
+	record    *Record
+	expected  string
+}
+
+func TestECS(t *testing.T) {
+
+	timestamp := Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 123456789, time.FixedZone("EST", -5*60*60)), Format: time.RFC3339}
+	service := NewECS(WithServiceName("api"), WithServiceVersion("1.2.3"), WithServiceEnvironment("production"), WithServiceName("web"), WithECSFields(Field{"host.name", "host1"}))
+
+	tests := []ecsTest{
+		{
+			ECS,
+			&Record{Timestamp: timestamp, Level: 4, LogLevel: "INFO", Variables: []interface{}{"Test string", 123, Field{"user.name", "alice"}}, Tags: []string{"tag1", "tag2"}},
+			`{"@timestamp":"2006-01-02T20:04:05.123Z","log.level":"info","message":"Test string 123","ecs.version":"` + ECSVersion + `","tags":["tag1","tag2"],"user.name":"alice"}`,
+		},
+		{
+			service,
+			&Record{Timestamp: timestamp, Level: 2, LogLevel: "ERROR", Variables: []interface{}{"request failed:", errors.New("timeout"), Field{"ratio", math.NaN()}}},
+			`{"@timestamp":"2006-01-02T20:04:05.123Z","log.level":"error","message":"request failed: timeout","ecs.version":"` + ECSVersion + `","error.message":"timeout","error.type":"*errors.errorString","service.name":"web","service.version":"1.2.3","service.environment":"production","host.name":"host1","ratio":"NaN"}`,
+		},
+		{
+			ECS,
+			&Record{Timestamp: timestamp, Level: 1, LogLevel: "FATAL", Variables: []interface{}{"crashed", Field{"error", stackError{}}}, Caller: Caller{File: "/src/app/main.go", Line: 12, Function: "main.run"}},
+			`{"@timestamp":"2006-01-02T20:04:05.123Z","log.level":"fatal","message":"crashed","ecs.version":"` + ECSVersion + `","log.origin.file.name":"main.go","log.origin.file.line":12,"log.origin.function":"main.run","error.message":"failed","error.type":"formatters.stackError","error.stack_trace":"failed\nmain.run\n\tmain.go:12"}`,
+		},
+		{
+			NewECS(WithECSFields(Field{"message", "static"})),
+			&Record{Timestamp: timestamp, Level: 2, LogLevel: "ERROR", Variables: []interface{}{"failed", Field{"error", "other"}, Field{"log", "x"}, Field{"error.code", 5}, Field{"ecs.version.x", 1}}, Err: errors.New("timeout")},
+			`{"@timestamp":"2006-01-02T20:04:05.123Z","log.level":"error","message":"failed","ecs.version":"` + ECSVersion + `","error.message":"timeout","error.type":"*errors.errorString","labels.message":"static","labels.error":"other","labels.log":"x","error.code":5,"labels.ecs.version.x":1}`,
+		},
+		{
+			service,
+			&Record{Timestamp: timestamp, Level: 3, LogLevel: "WARN", Variables: []interface{}{"slow", Field{"error", "retrying"}, Field{"service", "x"}}},
+			`{"@timestamp":"2006-01-02T20:04:05.123Z","log.level":"warn","message":"slow","ecs.version":"` + ECSVersion + `","service.name":"web","service.version":"1.2.3","service.environment":"production","host.name":"host1","labels.error":"retrying","labels.service":"x"}`,
+		},
+		{
+			ECS,
+			&Record{Timestamp: timestamp, Level: 2, LogLevel: "ERROR", Variables: []interface{}{"request failed:", (*pointerError)(nil), &pointerError{"timeout"}}},
+			`{"@timestamp":"2006-01-02T20:04:05.123Z","log.level":"error","message":"request failed: \u003cnil\u003e timeout","ecs.version":"` + ECSVersion + `","error.message":"timeout","error.type":"*formatters.pointerError"}`,
+		},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Call the function
+		b, err := test.formatter.Format(nil, test.record, &RenderContext{})
+		if err != nil {
+			t.Error(err)
+		}
+
+		// Check if the output is correct
+		if output := string(b); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, test.expected, output)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package formatters
+
+import (
+	"math"
+	"strconv"
+)
+
+//
+// Structures
+//
+
+// emf holds the settings of a CloudWatch Embedded Metric Format formatter.
+type emf struct {
+	namespace  string
+	dimensions []string
+	fields     []Field
+}
+
+// An EMFOption is a function that sets an option on a CloudWatch Embedded Metric Format formatter.
+type EMFOption func(e *emf)
+
+//
+// Constructors
+//
+
+// NewEMF will create a formatter that formats logs into AWS CloudWatch Embedded Metric Format (EMF) JSON.
+// Any metrics in the variables (See `Metric`) are published to CloudWatch under the given namespace.
+// Logs without metrics are written as plain structured JSON which CloudWatch Logs can still query.
+// The log level, message and tags are written as `level`, `message` and `tags` and fields are written as top-level keys.
+func NewEMF(namespace string, opts ...EMFOption) Func {
+
+	e := &emf{namespace: namespace}
+
+	// Apply the custom options
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e.format
+}
+
+//
+// Functional Options
+//
+
+// WithDimensions will return a function that sets the keys of the fields that metrics are grouped by (e.g. "Service").
+// Only the dimensions that a log has a field for are used and their values are always written as strings.
+func WithDimensions(dimensions ...string) EMFOption {
+	return func(e *emf) {
+		e.dimensions = append(e.dimensions, dimensions...)
+	}
+}
+
+// WithEMFFields will return a function that adds the given fields to every log (e.g. `Field{"Service", "api"}`).
+func WithEMFFields(fields ...Field) EMFOption {
+	return func(e *emf) {
+		e.fields = append(e.fields, fields...)
+	}
+}
+
+//
+// Instance methods
+//
+
+// format will format a log into CloudWatch Embedded Metric Format JSON.
+// The log level and tags are never colored.
+func (e *emf) format(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+
+	dst = append(dst, '{')
+
+	// If there are metrics, add the metadata that tells CloudWatch to publish them
+	if e.hasMetrics(record) {
+		dst = append(dst, `"_aws":{"Timestamp":`...)
+		dst = strconv.AppendInt(dst, record.Timestamp.Time.UnixNano()/1e6, 10)
+		dst = append(dst, `,"CloudWatchMetrics":[{"Namespace":`...)
+		dst = appendJSONString(dst, e.namespace)
+		dst = append(dst, `,"Dimensions":[[`...)
+		first := true
+		for _, dimension := range e.dimensions {
+			if e.hasField(record, dimension) {
+				if !first {
+					dst = append(dst, ',')
+				}
+				first = false
+				dst = appendJSONString(dst, dimension)
+			}
+		}
+		dst = append(dst, `]],"Metrics":[`...)
+		first = true
+		for _, variable := range record.Variables {
+			if metric, ok := variable.(Metric); ok && isFinite(metric.Value) {
+				if !first {
+					dst = append(dst, ',')
+				}
+				first = false
+				dst = append(dst, `{"Name":`...)
+				dst = appendJSONString(dst, metric.Name)
+				if metric.Unit != "" {
+					dst = append(dst, `,"Unit":`...)
+					dst = appendJSONString(dst, metric.Unit)
+				}
+				dst = append(dst, '}')
+			}
+		}
+		dst = append(dst, `]}]},`...)
+	}
+
+	// Add the log level and message. Markup is always removed
+	dst = append(dst, `"level":`...)
+	dst = appendJSONString(dst, record.LogLevel)
+	dst = append(dst, `,"message":`...)
+	message := appendMessage(nil, record, sanitizer{markup: newMarkup(ctx, false, "")})
+	dst = appendJSONString(dst, string(message))
+
+	// If there are tags, add them to the output
+	if len(record.Tags) > 0 {
+		dst = append(dst, `,"tags":[`...)
+		for i, tag := range record.Tags {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, tag)
+		}
+		dst = append(dst, ']')
+	}
+
+	// Add the static fields, the log's fields and the metric values
+	for _, field := range e.fields {
+		dst = e.appendField(dst, field)
+	}
+	for _, variable := range record.Variables {
+		switch v := variable.(type) {
+		case Field:
+			dst = e.appendField(dst, v)
+		case Metric:
+			if isFinite(v.Value) {
+				dst = append(dst, ',')
+				dst = appendJSONString(dst, v.Name)
+				dst = append(dst, ':')
+				dst, _ = appendJSONFloat(dst, v.Value, 64)
+			}
+		}
+	}
+
+	return append(dst, '}'), nil
+}
+
+// appendField will append a field to dst. Dimensions are always written as strings.
+func (e *emf) appendField(dst []byte, field Field) []byte {
+
+	for _, dimension := range e.dimensions {
+		if field.Key == dimension {
+			dst = append(dst, ',')
+			dst = appendJSONString(dst, field.Key)
+			dst = append(dst, ':')
+			return appendJSONString(dst, string(appendValue(nil, field.Value)))
+		}
+	}
+
+	return appendJSONField(dst, field)
+}
+
+// hasMetrics will return whether or not the log has any metrics that can be published.
+func (e *emf) hasMetrics(record *Record) bool {
+	for _, variable := range record.Variables {
+		if metric, ok := variable.(Metric); ok && isFinite(metric.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasField will return whether or not the log or the formatter has a field with the given key.
+func (e *emf) hasField(record *Record, key string) bool {
+	for _, field := range e.fields {
+		if field.Key == key {
+			return true
+		}
+	}
+	for _, variable := range record.Variables {
+		if field, ok := variable.(Field); ok && field.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// isFinite will return whether or not a float can be encoded as JSON.
+func isFinite(f float64) bool {
+	return !math.IsInf(f, 0) && !math.IsNaN(f)
+}
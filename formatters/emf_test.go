@@ -0,0 +1,22 @@
+package formatters
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEMF(t *testing.T) {
+
+	timestamp := Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 123456789, time.UTC), Format: time.RFC3339}
+
+	records := []*Record{
+		{Timestamp: timestamp, Level: 4, LogLevel: "INFO", Variables: []interface{}{"Test string", 123}, Tags: []string{"tag1", "tag2"}},
+		{Timestamp: timestamp, Level: 4, LogLevel: "INFO", Variables: []interface{}{"request done", Field{"Operation", "GetUser"}, testMetric(), Metric{Name: "requests", Value: 1}}},
+		{Timestamp: timestamp, Level: 3, LogLevel: "WARN", Variables: []interface{}{"slow request", Field{"Operation", 42}, Metric{Name: "latency", Value: 2500, Unit: "Milliseconds"}, Metric{Name: "invalid", Value: math.Inf(1)}}},
+		{Timestamp: timestamp, Level: 2, LogLevel: "ERROR", Variables: []interface{}{"no metrics", Metric{Name: "invalid", Value: math.NaN()}}},
+	}
+
+	formatter := NewEMF("plog/test", WithDimensions("Service", "Operation"), WithEMFFields(Field{"Service", "api"}))
+	checkGolden(t, "emf", formatGolden(t, formatter, records))
+}
@@ -0,0 +1,15 @@
+package formatters
+
+import "fmt"
+
+// A Field is a key/value pair that can be passed to any logging function alongside the other variables.
+// Formatters can choose to display fields separately from the rest of the message.
+type Field struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// String will stringify the field into the format 'key=value'.
+func (field Field) String() string {
+	return fmt.Sprintf("%s=%v", field.Key, field.Value)
+}
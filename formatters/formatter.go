@@ -0,0 +1,55 @@
+package formatters
+
+// A Func is a function that appends a formatted record to dst and returns the extended buffer.
+// Func implements the `plog.RecordFormatter` interface.
+type Func func(dst []byte, record *Record, ctx *RenderContext) ([]byte, error)
+
+// Format calls formatter(dst, record, ctx).
+func (formatter Func) Format(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+	return formatter(dst, record, ctx)
+}
+
+// formatStrings will format a log that has already been rendered into strings using the given record formatter.
+// This lets the formatting functions (e.g. `Text`) keep the signature they had before formatters received records.
+// The timestamp is written as it is and any colors around the log level and tags are passed to the formatter as styles.
+func formatStrings(formatter Func, timestamp, logLevel string, variables []interface{}, tags []string) (string, error) {
+
+	record := &Record{
+		Timestamp: Timestamp{Rendered: timestamp},
+		Variables: variables,
+		Tags:      make([]string, len(tags)),
+	}
+	ctx := &RenderContext{
+		TagStyles: make([]Style, len(tags)),
+	}
+
+	// Remove the colors from the log level and tags
+	record.LogLevel, ctx.LogLevelStyle = splitStyle(logLevel)
+	for i, tag := range tags {
+		record.Tags[i], ctx.TagStyles[i] = splitStyle(tag)
+	}
+
+	b, err := formatter(nil, record, ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// splitStyle will split a string that has been colored (e.g. "\x1b[31mtext\x1b[0m") into its text and style.
+// Strings that are not wrapped in a style are returned as they are.
+func splitStyle(s string) (string, Style) {
+
+	// Find the end of any escape sequences at the start of the string
+	i := 0
+	for i < len(s) && s[i] == 0x1b {
+		i = skipEscapeSequence(s, i)
+	}
+
+	if i == 0 || len(s)-len(reset) < i || s[len(s)-len(reset):] != reset {
+		return s, ""
+	}
+
+	return s[i : len(s)-len(reset)], Style(s[:i])
+}
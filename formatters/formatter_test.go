@@ -0,0 +1,30 @@
+package formatters
+
+import "testing"
+
+func TestFormatStrings(t *testing.T) {
+
+	tests := []struct {
+		formatter func(timestamp, logLevel string, variables []interface{}, tags []string) (string, error)
+		expected  string
+	}{
+		{Text, "2006-01-02T15:04:05Z [\x1b[32mINFO\x1b[0m] [\x1b[31m#tag1\x1b[0m #tag2] Test string 123"},
+		{JSON, `{"timestamp":"2006-01-02T15:04:05Z","logLevel":"INFO","variables":["Test string",123],"tags":["tag1","tag2"]}`},
+		{CSV, "2006-01-02T15:04:05Z,INFO,Test string 123,tag1:tag2"},
+		{Plain, "Test string 123"},
+	}
+
+	for i, test := range tests {
+
+		// Call the formatting function with a colored log level and tag
+		output, err := test.formatter("2006-01-02T15:04:05Z", "\x1b[32mINFO\x1b[0m", []interface{}{"Test string", 123}, []string{"\x1b[31mtag1\x1b[0m", "tag2"})
+		if err != nil {
+			t.Errorf("[%d] %v", i, err)
+		}
+
+		// Check if the output is correct
+		if output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", i, test.expected, output)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package formatters
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields with these keys are written as the trace fields that Google Cloud Logging uses to correlate logs with traces.
+const (
+	GCPTraceKey        = "trace"
+	GCPSpanIDKey       = "spanId"
+	GCPTraceSampledKey = "traceSampled"
+)
+
+// GCP will format a log into the structured JSON that Google Cloud Logging reads from stdout (e.g. on GKE or Cloud Run).
+// Use `NewGCP` to set the project ID that trace IDs belong to.
+var GCP = NewGCP()
+
+//
+// Structures
+//
+
+// gcp holds the settings of a Google Cloud Logging formatter.
+type gcp struct {
+	projectID string
+}
+
+// A GCPOption is a function that sets an option on a Google Cloud Logging formatter.
+type GCPOption func(g *gcp)
+
+//
+// Constructors
+//
+
+// NewGCP will create a formatter that formats logs into the structured JSON that Google Cloud Logging reads from stdout.
+// Log levels are written as severities, tags are written as labels and the caller (See `plog.WithCaller`) is written as the source location.
+// Fields with the keys `GCPTraceKey`, `GCPSpanIDKey` and `GCPTraceSampledKey` are written as trace fields and any other fields are written as top-level keys.
+func NewGCP(opts ...GCPOption) Func {
+
+	g := &gcp{}
+
+	// Apply the custom options
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g.format
+}
+
+//
+// Functional Options
+//
+
+// WithProjectID will return a function that sets the Google Cloud project that trace IDs belong to.
+// Trace IDs are written as "projects/<projectID>/traces/<traceID>" unless they already start with "projects/".
+func WithProjectID(projectID string) GCPOption {
+	return func(g *gcp) {
+		g.projectID = projectID
+	}
+}
+
+//
+// Instance methods
+//
+
+// format will format a log into Google Cloud Logging structured JSON.
+// The log level and tags are never colored.
+func (g *gcp) format(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+
+	// Add the timestamp, severity and message. Markup is always removed
+	dst = append(dst, `{"timestamp":"`...)
+	dst = record.Timestamp.Time.UTC().AppendFormat(dst, time.RFC3339Nano)
+	dst = append(dst, `","severity":"`...)
+	dst = append(dst, gcpSeverity(record.Level)...)
+	dst = append(dst, `","message":`...)
+	message := appendMessage(nil, record, sanitizer{markup: newMarkup(ctx, false, "")})
+	dst = appendJSONString(dst, string(message))
+
+	// If there are tags, add them as labels
+	if len(record.Tags) > 0 {
+		dst = append(dst, `,"logging.googleapis.com/labels":{`...)
+		for i, tag := range record.Tags {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, tag)
+			dst = append(dst, `:"true"`...)
+		}
+		dst = append(dst, '}')
+	}
+
+	// Add the location of the code that wrote the log
+	if record.Caller.File != "" {
+		dst = append(dst, `,"logging.googleapis.com/sourceLocation":{"file":`...)
+		dst = appendJSONString(dst, record.Caller.File)
+		dst = append(dst, `,"line":"`...)
+		dst = strconv.AppendInt(dst, int64(record.Caller.Line), 10)
+		dst = append(dst, `","function":`...)
+		dst = appendJSONString(dst, record.Caller.Function)
+		dst = append(dst, '}')
+	}
+
+	// Add the fields, writing the trace fields under their special keys
+	for _, variable := range record.Variables {
+		field, ok := asField(variable)
+		if !ok {
+			continue
+		}
+		switch field.Key {
+		case GCPTraceKey:
+			dst = append(dst, `,"logging.googleapis.com/trace":`...)
+			dst = appendJSONString(dst, g.trace(string(appendValue(nil, field.Value))))
+		case GCPSpanIDKey:
+			dst = append(dst, `,"logging.googleapis.com/spanId":`...)
+			dst = appendJSONString(dst, string(appendValue(nil, field.Value)))
+		case GCPTraceSampledKey:
+			sampled, _ := field.Value.(bool)
+			dst = append(dst, `,"logging.googleapis.com/trace_sampled":`...)
+			dst = strconv.AppendBool(dst, sampled)
+		default:
+			dst = appendJSONField(dst, field)
+		}
+	}
+
+	return append(dst, '}'), nil
+}
+
+// trace will return the full resource name of a trace.
+func (g *gcp) trace(traceID string) string {
+	if g.projectID == "" || strings.HasPrefix(traceID, "projects/") {
+		return traceID
+	}
+	return "projects/" + g.projectID + "/traces/" + traceID
+}
+
+// gcpSeverity will convert a numeric log level (1 = FATAL ... 6 = TRACE) into a Google Cloud Logging severity.
+func gcpSeverity(level int) string {
+	switch level {
+	case 1:
+		return "CRITICAL"
+	case 2:
+		return "ERROR"
+	case 3:
+		return "WARNING"
+	case 4:
+		return "INFO"
+	default:
+		return "DEBUG"
+	}
+}
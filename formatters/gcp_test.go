@@ -0,0 +1,28 @@
+package formatters
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGCP(t *testing.T) {
+
+	timestamp := Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 123456789, time.UTC), Format: time.RFC3339}
+
+	records := []*Record{
+		{Timestamp: timestamp, Level: 4, LogLevel: "INFO", Variables: []interface{}{"Test string", 123}, Tags: []string{"tag1", "tag2"}},
+		{Timestamp: timestamp, Level: 1, LogLevel: "FATAL", Variables: []interface{}{"crashed"}, Caller: Caller{File: "/src/app/main.go", Line: 12, Function: "main.run"}},
+		{Timestamp: timestamp, Level: 2, LogLevel: "ERROR", Variables: []interface{}{"request failed", Field{GCPTraceKey, "abc123"}, Field{GCPSpanIDKey, "000000000000004a"}, Field{GCPTraceSampledKey, true}, Field{"error", errors.New("timeout")}}},
+		{Timestamp: timestamp, Level: 3, LogLevel: "WARN", Template: "user {user} logged in", Variables: []interface{}{Field{"user", "alice"}, Field{GCPTraceKey, "projects/other/traces/def456"}}},
+		{Timestamp: timestamp, Level: 5, LogLevel: "DEBUG", Variables: []interface{}{"<b>Test</b> string", Field{"latency", 1.5}}},
+		{Timestamp: timestamp, Level: 6, LogLevel: "TRACE", Variables: []interface{}{testMetric()}},
+	}
+
+	checkGolden(t, "gcp", formatGolden(t, NewGCP(WithProjectID("my-project")), records))
+}
+
+// testMetric will return a metric used by several golden files.
+func testMetric() Metric {
+	return Metric{Name: "latency", Value: 12.5, Unit: "Milliseconds"}
+}
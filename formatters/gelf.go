@@ -0,0 +1,171 @@
+package formatters
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+// GELF will format a log into a Graylog Extended Log Format (GELF) 1.1 message.
+// The host is set to the name of the machine. Use `NewGELF` to set a different host.
+var GELF = NewGELF(hostname())
+
+// NewGELF will create a formatter that formats logs into GELF 1.1 messages from the given host.
+// Tags are added as the `_tags` field and each field is added as its own additional field (e.g. `_user`).
+func NewGELF(host string) Func {
+	return func(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+		return gelf(dst, host, record, ctx)
+	}
+}
+
+// gelf will format a log into a GELF 1.1 message.
+// The log level and tags are never colored.
+func gelf(dst []byte, host string, record *Record, ctx *RenderContext) ([]byte, error) {
+
+	// Add the version and host
+	dst = append(dst, `{"version":"1.1","host":`...)
+	dst = appendJSONString(dst, host)
+
+	// Render the message. Markup is always removed
+	message := appendMessage(nil, record, sanitizer{markup: newMarkup(ctx, false, "")})
+	if len(message) == 0 {
+		message = append(message, '-')
+	}
+
+	// The short message is the first line of the message. The full message is only needed for multi-line messages
+	short := message
+	for i, c := range message {
+		if c == '\n' || c == '\r' {
+			short = message[:i]
+			break
+		}
+	}
+	dst = append(dst, `,"short_message":`...)
+	dst = appendJSONString(dst, string(short))
+	if len(short) < len(message) {
+		dst = append(dst, `,"full_message":`...)
+		dst = appendJSONString(dst, string(message))
+	}
+
+	// Add the timestamp and level
+	dst = append(dst, `,"timestamp":`...)
+	dst = appendUnixSeconds(dst, record.Timestamp.Time)
+	dst = append(dst, `,"level":`...)
+	dst = strconv.AppendInt(dst, int64(SyslogSeverity(record.Level)), 10)
+
+	// Add the log level name, template and tags as additional fields
+	dst = append(dst, `,"_log_level":`...)
+	dst = appendJSONString(dst, record.LogLevel)
+	if record.Template != "" {
+		dst = append(dst, `,"_template":`...)
+		dst = appendJSONString(dst, record.Template)
+	}
+	if len(record.Tags) > 0 {
+		var tags []byte
+		for i, tag := range record.Tags {
+			if i > 0 {
+				tags = append(tags, ',')
+			}
+			tags = append(tags, tag...)
+		}
+		dst = append(dst, `,"_tags":`...)
+		dst = appendJSONString(dst, string(tags))
+	}
+
+	// Add each field as an additional field
+	for _, variable := range record.Variables {
+		if field, ok := asField(variable); ok {
+			dst = append(dst, `,"_`...)
+			dst = appendGELFKey(dst, field.Key)
+			dst = append(dst, `":`...)
+			dst = appendGELFValue(dst, field.Value)
+		}
+	}
+
+	return append(dst, '}'), nil
+}
+
+// SyslogSeverity will convert a numeric log level (1 = FATAL ... 6 = TRACE) into a syslog severity.
+// FATAL is critical (2), ERROR is error (3), WARN is warning (4), INFO is informational (6) and DEBUG and TRACE are debug (7).
+func SyslogSeverity(level int) int {
+	switch level {
+	case 1:
+		return 2
+	case 2:
+		return 3
+	case 3:
+		return 4
+	case 4:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// appendUnixSeconds will append the number of seconds since the Unix epoch to dst, with up to microsecond precision.
+func appendUnixSeconds(dst []byte, t time.Time) []byte {
+
+	dst = strconv.AppendInt(dst, t.Unix(), 10)
+
+	// Add the fractional seconds without any trailing zeros
+	micros := t.Nanosecond() / int(time.Microsecond)
+	if micros == 0 {
+		return dst
+	}
+	digits := 6
+	for micros%10 == 0 {
+		micros /= 10
+		digits--
+	}
+	dst = append(dst, '.')
+	for i := digits - len(strconv.Itoa(micros)); i > 0; i-- {
+		dst = append(dst, '0')
+	}
+
+	return strconv.AppendInt(dst, int64(micros), 10)
+}
+
+// appendGELFKey will append the name of an additional field to dst.
+// GELF only allows letters, numbers, underscores, dashes and dots, so any other characters are replaced with underscores.
+// The name "id" is reserved by GELF, so it is written as "_id". Empty names are written as "_".
+func appendGELFKey(dst []byte, key string) []byte {
+
+	switch key {
+	case "":
+		return append(dst, '_')
+	case "id":
+		return append(dst, "_id"...)
+	}
+
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.') {
+			c = '_'
+		}
+		dst = append(dst, c)
+	}
+
+	return dst
+}
+
+// appendGELFValue will append the value of an additional field to dst.
+// GELF only allows strings and numbers, so any other values are rendered as strings.
+func appendGELFValue(dst []byte, value interface{}) []byte {
+
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		if b, err := appendJSONValue(dst, value); err == nil {
+			return b
+		}
+	}
+
+	return appendJSONString(dst, string(appendValue(nil, value)))
+}
+
+// hostname will return the name of the machine, or "localhost" if it is not known.
+func hostname() string {
+	if host, err := os.Hostname(); err == nil && host != "" {
+		return host
+	}
+	return "localhost"
+}
@@ -0,0 +1,50 @@
+package formatters
+
+import (
+	"testing"
+	"time"
+)
+
+type gelfTest struct {
+	record   *Record
+	expected string
+}
+
+func TestGELF(t *testing.T) {
+
+	timestamp := Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 123000000, time.UTC), Format: time.RFC3339}
+
+	tests := []gelfTest{
+		{
+			&Record{Timestamp: timestamp, Level: 4, LogLevel: "INFO", Variables: []interface{}{"Test string", 123, Field{"user", "alice"}, Field{"count", 3}}, Tags: []string{"tag1", "tag2"}},
+			`{"version":"1.1","host":"host1","short_message":"Test string 123","timestamp":1136214245.123,"level":6,"_log_level":"INFO","_tags":"tag1,tag2","_user":"alice","_count":3}`,
+		},
+		{
+			&Record{Timestamp: timestamp, Level: 2, LogLevel: "ERROR", Variables: []interface{}{"failed\nstack trace", Field{"id", 1}, Field{"user id", true}, Field{"elapsed", time.Second}}},
+			`{"version":"1.1","host":"host1","short_message":"failed","full_message":"failed\nstack trace","timestamp":1136214245.123,"level":3,"_log_level":"ERROR","__id":1,"_user_id":"true","_elapsed":"1s"}`,
+		},
+		{
+			&Record{Timestamp: timestamp, Level: 1, LogLevel: "FATAL", Template: "user {user} logged in", Variables: []interface{}{Field{"user", "alice"}}},
+			`{"version":"1.1","host":"host1","short_message":"user alice logged in","timestamp":1136214245.123,"level":2,"_log_level":"FATAL","_template":"user {user} logged in","_user":"alice"}`,
+		},
+		{
+			&Record{Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC)}, Level: 6, LogLevel: "TRACE", Variables: []interface{}{Field{"empty", ""}}},
+			`{"version":"1.1","host":"host1","short_message":"-","timestamp":1136214245,"level":7,"_log_level":"TRACE","_empty":""}`,
+		},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Call the function
+		b, err := NewGELF("host1").Format(nil, test.record, &RenderContext{})
+		if err != nil {
+			t.Error(err)
+		}
+
+		// Check if the output is correct
+		if output := string(b); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, test.expected, output)
+		}
+	}
+}
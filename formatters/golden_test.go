@@ -0,0 +1,58 @@
+package formatters
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// Run `go test ./formatters -update` to rewrite the golden files after changing a formatter.
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// checkGolden will compare the output of a formatter with the contents of a golden file in testdata.
+func checkGolden(t *testing.T, name string, output []byte) {
+
+	path := filepath.Join("testdata", name+".golden")
+
+	// Rewrite the golden file if requested
+	if *update {
+		if err := ioutil.WriteFile(path, output, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Compare each line so that differences are easy to find
+	expectedLines := bytes.Split(expected, []byte("\n"))
+	outputLines := bytes.Split(output, []byte("\n"))
+	if len(expectedLines) != len(outputLines) {
+		t.Errorf("Incorrect output. Expected %d lines in '%s', received %d", len(expectedLines), path, len(outputLines))
+	}
+	for i := 0; i < len(expectedLines) && i < len(outputLines); i++ {
+		if !bytes.Equal(expectedLines[i], outputLines[i]) {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, expectedLines[i], outputLines[i])
+		}
+	}
+}
+
+// formatGolden will format each record on its own line.
+func formatGolden(t *testing.T, formatter Func, records []*Record) []byte {
+
+	var output []byte
+	for _, record := range records {
+		var err error
+		if output, err = formatter.Format(output, record, &RenderContext{}); err != nil {
+			t.Error(err)
+		}
+		output = append(output, '\n')
+	}
+
+	return output
+}
@@ -1,27 +1,346 @@
 package formatters
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 )
 
+// The maximum number of wrapped errors that are written in an error's chain.
+const maxErrorChain = 32
+
+// JSONRecord will format a record into a Javascript object notation (JSON) string.
+// Use `NewJSON` to rename keys, flatten messages or indent the output.
+var JSONRecord = NewJSON()
+
 // JSON will format a log into a Javascript object notation (JSON) string.
+// Loggers use JSONRecord instead, which receives the raw timestamp and the log level's name (See `plog.WithFormatter`).
 func JSON(timestamp, logLevel string, variables []interface{}, tags []string) (string, error) {
+	return formatStrings(JSONRecord, timestamp, logLevel, variables, tags)
+}
+
+//
+// Structures
+//
+
+// JSONKeys are the keys that a JSON formatter writes. Empty keys keep their default names.
+type JSONKeys struct {
+	Timestamp string // Default: "timestamp"
+	LogLevel  string // Default: "logLevel"
+	Template  string // Default: "template"
+	Message   string // Default: "message"
+	Variables string // Default: "variables"
+	Tags      string // Default: "tags"
+	Error     string // Default: "error"
+}
+
+// jsonFormatter holds the settings of a JSON formatter.
+// The keys are stored with their quotes and separators so that they can be appended directly.
+type jsonFormatter struct {
+	keys         JSONKeys
+	flatten      bool
+	indent       string
+	errorObjects bool
+	timestamp    string
+	logLevel     string
+	template     string
+	message      string
+	variables    string
+	tags         string
+	error        string
+}
+
+// A JSONOption is a function that sets an option on a JSON formatter.
+type JSONOption func(j *jsonFormatter)
+
+//
+// Constructors
+//
+
+// NewJSON will create a formatter that formats logs into JSON objects.
+// The log level is never colored. Errors are encoded in the same way as `json.Marshal()` unless `WithJSONErrorObjects` is used.
+// Values that cannot be encoded (e.g. channels, functions or cyclic values) are written as strings so that the rest of the log is kept.
+func NewJSON(opts ...JSONOption) Func {
+
+	j := &jsonFormatter{
+		keys: JSONKeys{
+			Timestamp: "timestamp",
+			LogLevel:  "logLevel",
+			Template:  "template",
+			Message:   "message",
+			Variables: "variables",
+			Tags:      "tags",
+			Error:     "error",
+		},
+	}
+
+	// Apply the custom options
+	for _, opt := range opts {
+		opt(j)
+	}
+
+	// Render the keys
+	j.timestamp = "{" + jsonKey(j.keys.Timestamp)
+	j.logLevel = "," + jsonKey(j.keys.LogLevel)
+	j.template = "," + jsonKey(j.keys.Template)
+	j.message = "," + jsonKey(j.keys.Message)
+	j.variables = "," + jsonKey(j.keys.Variables) + "["
+	j.tags = "," + jsonKey(j.keys.Tags) + "["
+	j.error = "," + jsonKey(j.keys.Error)
+
+	return j.format
+}
+
+//
+// Functional Options
+//
 
-	// Encode the log parameters as a JSON string
-	b, err := json.Marshal(struct {
-		Timestamp string        `json:"timestamp"`
-		LogLevel  string        `json:"logLevel"`
-		Variables []interface{} `json:"variables,omitempty"`
-		Tags      []string      `json:"tags,omitempty"`
-	}{
-		timestamp,
-		logLevel,
-		variables,
-		tags,
-	})
-	if err != nil {
-		return "", err
-	}
-
-	return string(b), nil
+// WithJSONKeys will return a function that renames the keys written by a JSON formatter (e.g. `JSONKeys{Timestamp: "ts", LogLevel: "level"}`).
+// Any keys that are empty keep their current names.
+func WithJSONKeys(keys JSONKeys) JSONOption {
+	return func(j *jsonFormatter) {
+		for _, key := range []struct{ from, to *string }{
+			{&keys.Timestamp, &j.keys.Timestamp},
+			{&keys.LogLevel, &j.keys.LogLevel},
+			{&keys.Template, &j.keys.Template},
+			{&keys.Message, &j.keys.Message},
+			{&keys.Variables, &j.keys.Variables},
+			{&keys.Tags, &j.keys.Tags},
+			{&keys.Error, &j.keys.Error},
+		} {
+			if *key.from != "" {
+				*key.to = *key.from
+			}
+		}
+	}
+}
+
+// WithFlattenedMessage will return a function that sets whether or not a log with a single variable (not counting fields) writes it as the message.
+// e.g. `{"message":"Test string"}` instead of `{"variables":["Test string"]}`. Any fields are still written as variables.
+func WithFlattenedMessage(flatten bool) JSONOption {
+	return func(j *jsonFormatter) {
+		j.flatten = flatten
+	}
+}
+
+// WithJSONIndent will return a function that sets the indentation of each level of the JSON object (e.g. "  ").
+// If the indent is empty, the object is written on a single line.
+func WithJSONIndent(indent string) JSONOption {
+	return func(j *jsonFormatter) {
+		j.indent = indent
+	}
+}
+
+// WithJSONErrorObjects will return a function that sets whether or not errors are written as objects with their message, type and the chain of errors that they wrap.
+// e.g. `{"message":"query failed: timeout","type":"*fmt.wrapError","chain":[{"message":"timeout","type":"*errors.errorString"}]}`
+// If the error passed to the logging function was formatted into the message (e.g. by `plog.Error` or `plog.Errorf`), it is written as the "error" key (See `Record.Err`).
+func WithJSONErrorObjects(enabled bool) JSONOption {
+	return func(j *jsonFormatter) {
+		j.errorObjects = enabled
+	}
+}
+
+//
+// Instance methods
+//
+
+// format will format a log into a JSON object.
+// The log level and tags are never colored.
+func (j *jsonFormatter) format(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+
+	n := len(dst)
+
+	// Add the timestamp and log level
+	dst = append(dst, j.timestamp...)
+	dst = record.Timestamp.AppendJSON(dst)
+	dst = append(dst, j.logLevel...)
+	dst = appendJSONString(dst, record.LogLevel)
+
+	// Markup is always removed from JSON
+	strip := newMarkup(ctx, false, "")
+
+	// If the log has a template, add the template and the rendered message
+	variables := record.Variables
+	if record.Template != "" {
+		dst = append(dst, j.template...)
+		dst = appendJSONString(dst, record.Template)
+		dst = append(dst, j.message...)
+		message, _ := appendTemplate(nil, record.Template, record.Variables, sanitizer{markup: strip})
+		dst = appendJSONString(dst, string(message))
+	} else if j.flatten {
+
+		// If there is a single variable that isn't a field, write it as the message
+		message := -1
+		for i, variable := range variables {
+			if _, ok := asField(variable); ok {
+				continue
+			}
+			if message >= 0 {
+				message = -1
+				break
+			}
+			message = i
+		}
+		if message >= 0 {
+			dst = append(dst, j.message...)
+			dst = j.appendVariable(dst, variables[message], strip)
+			if len(variables) > 1 {
+				dst = append(dst, j.variables...)
+				first := true
+				for i, variable := range variables {
+					if i != message {
+						if !first {
+							dst = append(dst, ',')
+						}
+						first = false
+						dst = j.appendVariable(dst, variable, strip)
+					}
+				}
+				dst = append(dst, ']')
+			}
+			variables = nil
+		}
+	}
+
+	// If there are variables, add them to the output
+	if len(variables) > 0 {
+		dst = append(dst, j.variables...)
+		for i, variable := range variables {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = j.appendVariable(dst, variable, strip)
+		}
+		dst = append(dst, ']')
+	}
+
+	// If there are tags, add them to the output
+	if len(record.Tags) > 0 {
+		dst = append(dst, j.tags...)
+		for i, tag := range record.Tags {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, tag)
+		}
+		dst = append(dst, ']')
+	}
+
+	// If the error was formatted into the message, add it as an object
+	if j.errorObjects && !isNilError(record.Err) && !hasError(record.Variables) {
+		dst = append(dst, j.error...)
+		dst = appendJSONError(dst, record.Err)
+	}
+	dst = append(dst, '}')
+
+	// Indent the object if necessary
+	if j.indent != "" {
+		var buffer bytes.Buffer
+		if err := json.Indent(&buffer, dst[n:], "", j.indent); err != nil {
+			return dst[:n], err
+		}
+		dst = append(dst[:n], buffer.Bytes()...)
+	}
+
+	return dst, nil
+}
+
+// appendVariable will append a variable to dst, removing any markup from messages written with a format string.
+func (j *jsonFormatter) appendVariable(dst []byte, variable interface{}, strip *markup) []byte {
+	if message, ok := variable.(Markup); ok {
+		return appendJSONString(dst, string(sanitizer{markup: strip}.appendMarkup(nil, message)))
+	}
+	return appendTolerantJSON(dst, variable, j.errorObjects)
+}
+
+// hasError will return whether or not any of the variables are errors.
+func hasError(variables []interface{}) bool {
+	for _, variable := range variables {
+		if _, ok := variable.(error); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonKey will return the given key as a JSON string followed by a colon.
+func jsonKey(key string) string {
+	return string(append(appendJSONString(nil, key), ':'))
+}
+
+// appendTolerantJSON will append a variable to dst as JSON. Values that cannot be encoded are written as strings instead of failing.
+// Nil errors are written as null. If errorObjects is true, other errors are written as objects (See `appendJSONError`).
+func appendTolerantJSON(dst []byte, value interface{}, errorObjects bool) []byte {
+
+	switch v := value.(type) {
+	case Field:
+		dst = append(dst, `{"key":`...)
+		dst = appendJSONString(dst, v.Key)
+		dst = append(dst, `,"value":`...)
+		return append(appendTolerantJSON(dst, v.Value, errorObjects), '}')
+	case error:
+		if isNilError(v) {
+			return append(dst, "null"...)
+		}
+		if errorObjects {
+			return appendJSONError(dst, v)
+		}
+	}
+
+	n := len(dst)
+	b, err := appendJSONValue(dst, value)
+	if err == nil {
+		return b
+	}
+
+	// Floats that JSON does not support (e.g. NaN) are written as they would be printed
+	switch value.(type) {
+	case float32, float64:
+		return appendJSONString(b[:n], string(appendValue(nil, value)))
+	}
+
+	return appendJSONString(b[:n], "%!v("+err.Error()+")")
+}
+
+// appendJSONError will append an error to dst as an object with its message, type and the chain of errors that it wraps.
+// e.g. `{"message":"query failed: timeout","type":"*fmt.wrapError","chain":[{"message":"timeout","type":"*errors.errorString"}]}`
+func appendJSONError(dst []byte, err error) []byte {
+
+	dst = appendJSONErrorFields(dst, err)
+	dst = append(dst, `,"chain":[`...)
+
+	// Loop through the wrapped errors
+	for i, wrapped := 0, unwrap(err); !isNilError(wrapped) && i < maxErrorChain; i, wrapped = i+1, unwrap(wrapped) {
+		if i > 0 {
+			dst = append(dst, ',')
+		}
+		dst = append(appendJSONErrorFields(dst, wrapped), '}')
+	}
+
+	return append(dst, ']', '}')
+}
+
+// appendJSONErrorFields will append the start of an error object with its message and type to dst.
+func appendJSONErrorFields(dst []byte, err error) []byte {
+	dst = append(dst, `{"message":`...)
+	dst = appendJSONString(dst, err.Error())
+	dst = append(dst, `,"type":`...)
+	return appendJSONString(dst, fmt.Sprintf("%T", err))
+}
+
+// unwrap will return the error that an error wraps, or nil if it doesn't wrap one.
+// Errors from the standard library (`Unwrap() error`) and github.com/pkg/errors (`Cause() error`) are supported.
+// Errors that wrap several errors (`Unwrap() []error`) return the first one.
+func unwrap(err error) error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	case interface{ Unwrap() []error }:
+		if errs := e.Unwrap(); len(errs) > 0 {
+			return errs[0]
+		}
+	case interface{ Cause() error }:
+		return e.Cause()
+	}
+	return nil
 }
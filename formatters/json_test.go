@@ -1,6 +1,9 @@
 package formatters
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"testing"
 	"time"
 )
@@ -11,26 +14,237 @@ func TestJSON(t *testing.T) {
 	const expected = `{"timestamp":"2006-01-02T15:04:05Z","logLevel":"INFO","variables":["Test string",123,4.5,true],"tags":["tag1","tag2"]}`
 
 	// Test log
-	var (
-		logLevel  = "INFO"
-		variables = []interface{}{"Test string", 123, 4.5, true}
-		timestamp = time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC).Format(time.RFC3339)
-		tags      = []string{"tag1", "tag2"}
-	)
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Variables: []interface{}{"Test string", 123, 4.5, true},
+		Tags:      []string{"tag1", "tag2"},
+	}
+
+	// Call the function
+	b, err := JSONRecord.Format(nil, record, &RenderContext{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if output := string(b); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestJSONColor(t *testing.T) {
+
+	// Expected output
+	const expected = `{"timestamp":"2006-01-02T15:04:05Z","logLevel":"INFO","variables":["Test string"],"tags":["tag1"]}`
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Variables: []interface{}{"Test string"},
+		Tags:      []string{"tag1"},
+	}
+	ctx := &RenderContext{
+		ColorLogging:  true,
+		LogLevelStyle: "\x1b[32m",
+		TagStyles:     []Style{"\x1b[31m"},
+	}
 
 	// Call the function
-	output, err := JSON(
-		timestamp,
-		logLevel,
-		variables,
-		tags,
-	)
+	b, err := JSONRecord.Format(nil, record, ctx)
 	if err != nil {
 		t.Error(err)
 	}
 
 	// Check if the output is correct
-	if output != expected {
+	if output := string(b); output != expected {
 		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
 	}
 }
+
+func TestJSONTemplate(t *testing.T) {
+
+	// Expected output
+	const expected = `{"timestamp":"2006-01-02T15:04:05Z","logLevel":"INFO","template":"user {user} logged in from {ip}","message":"user alice logged in from 10.0.0.1","variables":[{"key":"user","value":"alice"},{"key":"ip","value":"10.0.0.1"},"extra"],"tags":["tag1"]}`
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Template:  "user {user} logged in from {ip}",
+		Variables: []interface{}{Field{"user", "alice"}, Field{"ip", "10.0.0.1"}, "extra"},
+		Tags:      []string{"tag1"},
+	}
+
+	// Call the function
+	b, err := JSONRecord.Format(nil, record, &RenderContext{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if output := string(b); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestJSONValues(t *testing.T) {
+
+	type cyclic struct {
+		Self *cyclic
+	}
+	cycle := &cyclic{}
+	cycle.Self = cycle
+
+	base := errors.New("timeout")
+	wrapped := fmt.Errorf("query failed: %w", base)
+	errorObjects := NewJSON(WithJSONErrorObjects(true))
+
+	tests := []struct {
+		formatter Func
+		variables []interface{}
+		expected  string
+	}{
+		{
+			JSONRecord,
+			[]interface{}{base, Field{"err", base}},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"ERROR","variables":[{},{"key":"err","value":{}}]}`,
+		},
+		{
+			JSONRecord,
+			[]interface{}{(*pointerError)(nil), Field{"err", (*pointerError)(nil)}},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"ERROR","variables":[null,{"key":"err","value":null}]}`,
+		},
+		{
+			errorObjects,
+			[]interface{}{base},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"ERROR","variables":[{"message":"timeout","type":"*errors.errorString","chain":[]}]}`,
+		},
+		{
+			errorObjects,
+			[]interface{}{wrapped},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"ERROR","variables":[{"message":"query failed: timeout","type":"*fmt.wrapError","chain":[{"message":"timeout","type":"*errors.errorString"}]}]}`,
+		},
+		{
+			errorObjects,
+			[]interface{}{Field{"err", base}},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"ERROR","variables":[{"key":"err","value":{"message":"timeout","type":"*errors.errorString","chain":[]}}]}`,
+		},
+		{
+			errorObjects,
+			[]interface{}{(*pointerError)(nil), Field{"err", (*pointerError)(nil)}},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"ERROR","variables":[null,{"key":"err","value":null}]}`,
+		},
+		{
+			JSONRecord,
+			[]interface{}{"before", make(chan int), func() {}, "after"},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"ERROR","variables":["before","%!v(json: unsupported type: chan int)","%!v(json: unsupported type: func())","after"]}`,
+		},
+		{
+			JSONRecord,
+			[]interface{}{math.NaN(), math.Inf(1), Field{"cycle", cycle}},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"ERROR","variables":["NaN","+Inf",{"key":"cycle","value":"%!v(json: unsupported value: encountered a cycle via *formatters.cyclic)"}]}`,
+		},
+	}
+
+	for i, test := range tests {
+
+		// Test log
+		record := &Record{
+			Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+			Level:     2,
+			LogLevel:  "ERROR",
+			Variables: test.variables,
+		}
+
+		// Call the function
+		b, err := test.formatter.Format(nil, record, &RenderContext{})
+		if err != nil {
+			t.Errorf("[%d] %v", i, err)
+		}
+
+		// Check if the output is correct
+		if output := string(b); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, test.expected, output)
+		}
+	}
+}
+
+func TestNewJSON(t *testing.T) {
+
+	tests := []struct {
+		opts      []JSONOption
+		template  string
+		variables []interface{}
+		expected  string
+	}{
+		{
+			[]JSONOption{WithJSONKeys(JSONKeys{Timestamp: "ts", LogLevel: "level", Tags: "labels"})},
+			"",
+			[]interface{}{"Test string"},
+			`{"ts":"2006-01-02T15:04:05Z","level":"INFO","variables":["Test string"],"labels":["tag1"]}`,
+		},
+		{
+			[]JSONOption{WithJSONKeys(JSONKeys{Template: "tmpl", Message: "msg"})},
+			"user {user} logged in",
+			[]interface{}{Field{"user", "alice"}},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"INFO","tmpl":"user {user} logged in","msg":"user alice logged in","variables":[{"key":"user","value":"alice"}],"tags":["tag1"]}`,
+		},
+		{
+			[]JSONOption{WithFlattenedMessage(true)},
+			"",
+			[]interface{}{"Test string"},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"INFO","message":"Test string","tags":["tag1"]}`,
+		},
+		{
+			[]JSONOption{WithFlattenedMessage(true), WithJSONKeys(JSONKeys{Message: "msg", Variables: "fields"})},
+			"",
+			[]interface{}{Field{"user", "alice"}, 123},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"INFO","msg":123,"fields":[{"key":"user","value":"alice"}],"tags":["tag1"]}`,
+		},
+		{
+			[]JSONOption{WithFlattenedMessage(true)},
+			"",
+			[]interface{}{"Test string", 123},
+			`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"INFO","variables":["Test string",123],"tags":["tag1"]}`,
+		},
+		{
+			[]JSONOption{WithJSONIndent("  ")},
+			"",
+			[]interface{}{"Test string"},
+			"{\n  \"timestamp\": \"2006-01-02T15:04:05Z\",\n  \"logLevel\": \"INFO\",\n  \"variables\": [\n    \"Test string\"\n  ],\n  \"tags\": [\n    \"tag1\"\n  ]\n}",
+		},
+	}
+
+	for i, test := range tests {
+
+		// Test log
+		record := &Record{
+			Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+			Level:     4,
+			LogLevel:  "INFO",
+			Template:  test.template,
+			Variables: test.variables,
+			Tags:      []string{"tag1"},
+		}
+
+		// Call the function with a colored context to check that the log level is never colored
+		ctx := &RenderContext{
+			ColorLogging:  true,
+			LogLevelStyle: "\x1b[32m",
+		}
+		b, err := NewJSON(test.opts...).Format([]byte("prefix "), record, ctx)
+		if err != nil {
+			t.Errorf("[%d] %v", i, err)
+		}
+
+		// Check if the output is correct
+		if output := string(b); output != "prefix "+test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, "prefix "+test.expected, output)
+		}
+	}
+}
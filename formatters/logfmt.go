@@ -0,0 +1,239 @@
+package formatters
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Logfmt will format a log into a logfmt string (e.g. `ts=2006-01-02T15:04:05Z level=info tags=a,b msg="Test string"`).
+// Fields are written as their own keys after the message. Any other variables make up the message.
+var Logfmt = Func(logfmt)
+
+// logfmt will format a log into a logfmt string.
+// The log level and tags are never colored.
+func logfmt(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+
+	// Add the timestamp and log level
+	dst = append(dst, "ts="...)
+	n := len(dst)
+	dst = quoteLogfmtValue(record.Timestamp.Append(dst), n)
+	dst = append(dst, " level="...)
+	for i := 0; i < len(record.LogLevel); i++ {
+		c := record.LogLevel[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		dst = append(dst, c)
+	}
+
+	// If there are tags, add them to the output
+	if len(record.Tags) > 0 {
+		dst = append(dst, " tags="...)
+		n = len(dst)
+		for i, tag := range record.Tags {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = append(dst, tag...)
+		}
+		dst = quoteLogfmtValue(dst, n)
+	}
+
+	// Add the message. Markup is always removed
+	strip := newMarkup(ctx, false, "")
+	dst = append(dst, " msg="...)
+	n = len(dst)
+	dst = appendMessage(dst, record, sanitizer{markup: strip})
+	dst = quoteLogfmtValue(dst, n)
+
+	// If the log has a template, add it so that logs can be grouped by template
+	if record.Template != "" {
+		dst = append(dst, " template="...)
+		n = len(dst)
+		dst = quoteLogfmtValue(append(dst, record.Template...), n)
+	}
+
+	// Add each field as its own key
+	for _, variable := range record.Variables {
+		if field, ok := asField(variable); ok {
+			dst = append(dst, ' ')
+			dst = appendLogfmtKey(dst, field.Key)
+			dst = append(dst, '=')
+			n = len(dst)
+			dst = quoteLogfmtValue(appendValue(dst, field.Value), n)
+		}
+	}
+
+	return dst, nil
+}
+
+// appendLogfmtKey will append a key to dst. Any characters that are not allowed in keys are replaced with underscores.
+func appendLogfmtKey(dst []byte, key string) []byte {
+
+	if key == "" {
+		return append(dst, '_')
+	}
+
+	for _, r := range key {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f || r == utf8.RuneError {
+			r = '_'
+		}
+		dst = appendRune(dst, r)
+	}
+
+	return dst
+}
+
+// quoteLogfmtValue will quote the value that starts at dst[n] if it is empty or contains spaces, quotes, '=' or control characters.
+func quoteLogfmtValue(dst []byte, n int) []byte {
+
+	if len(dst) > n && !needsLogfmtQuotes(dst[n:]) {
+		return dst
+	}
+
+	value := string(dst[n:])
+	dst = append(dst[:n], '"')
+
+	// Loop through the value and escape any special characters
+	for _, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			dst = append(dst, '\\', byte(r))
+		case r == '\n':
+			dst = append(dst, '\\', 'n')
+		case r == '\r':
+			dst = append(dst, '\\', 'r')
+		case r == '\t':
+			dst = append(dst, '\\', 't')
+		case r < ' ' || r == 0x7f:
+			dst = append(dst, '\\', 'u', '0', '0', hex[r>>4], hex[r&0xf])
+		default:
+			dst = appendRune(dst, r)
+		}
+	}
+
+	return append(dst, '"')
+}
+
+// needsLogfmtQuotes will return whether or not a value must be quoted.
+func needsLogfmtQuotes(value []byte) bool {
+	for _, c := range value {
+		if c <= ' ' || c == '=' || c == '"' || c == '\\' || c == 0x7f {
+			return true
+		}
+	}
+	return !utf8.Valid(value)
+}
+
+// ParseLogfmt will parse a single logfmt line into its keys and values, in the order that they appear.
+// Values are always strings. Keys without a value (e.g. `debug` in `debug level=info`) have an empty value.
+// This can be used to read back logs that were written with `Logfmt`.
+func ParseLogfmt(line string) (fields []Field, err error) {
+
+	for i := 0; i < len(line); {
+
+		// Skip spaces
+		if line[i] == ' ' || line[i] == '\t' || line[i] == '\r' || line[i] == '\n' {
+			i++
+			continue
+		}
+
+		// Read the key
+		start := i
+		for i < len(line) && line[i] > ' ' && line[i] != '=' && line[i] != '"' {
+			i++
+		}
+		if i == start {
+			return nil, fmt.Errorf("Invalid logfmt: unexpected '%c' at position %d", line[i], i)
+		}
+		key := line[start:i]
+
+		// Keys without values
+		if i == len(line) || line[i] != '=' {
+			fields = append(fields, Field{Key: key, Value: ""})
+			continue
+		}
+		i++
+
+		// Read a quoted value
+		if i < len(line) && line[i] == '"' {
+			var value string
+			if value, i, err = parseLogfmtQuoted(line, i); err != nil {
+				return nil, err
+			}
+			fields = append(fields, Field{Key: key, Value: value})
+			continue
+		}
+
+		// Read a bare value
+		start = i
+		for i < len(line) && line[i] > ' ' {
+			if line[i] == '"' || line[i] == '=' {
+				return nil, fmt.Errorf("Invalid logfmt: unexpected '%c' at position %d", line[i], i)
+			}
+			i++
+		}
+		fields = append(fields, Field{Key: key, Value: line[start:i]})
+	}
+
+	return fields, nil
+}
+
+// parseLogfmtQuoted will parse the quoted value that starts at line[i] and return the value and the position after it.
+func parseLogfmtQuoted(line string, i int) (string, int, error) {
+
+	var value []byte
+
+	for i++; i < len(line); i++ {
+		switch c := line[i]; c {
+		case '"':
+			return string(value), i + 1, nil
+		case '\\':
+			if i+1 == len(line) {
+				return "", 0, errors.New("Invalid logfmt: unterminated escape sequence")
+			}
+			i++
+			switch line[i] {
+			case '"', '\\':
+				value = append(value, line[i])
+			case 'n':
+				value = append(value, '\n')
+			case 'r':
+				value = append(value, '\r')
+			case 't':
+				value = append(value, '\t')
+			case 'u':
+				if i+5 > len(line) {
+					return "", 0, errors.New("Invalid logfmt: invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(line[i+1:i+5], 16, 32)
+				if err != nil {
+					return "", 0, errors.New("Invalid logfmt: invalid unicode escape")
+				}
+				value = appendRune(value, rune(r))
+				i += 4
+			default:
+				return "", 0, fmt.Errorf("Invalid logfmt: invalid escape sequence '\\%c'", line[i])
+			}
+		default:
+			value = append(value, c)
+		}
+	}
+
+	return "", 0, errors.New("Invalid logfmt: unterminated quoted value")
+}
+
+// appendRune will append the UTF-8 encoding of r to dst.
+func appendRune(dst []byte, r rune) []byte {
+
+	if r < utf8.RuneSelf {
+		return append(dst, byte(r))
+	}
+
+	var buf [utf8.UTFMax]byte
+	n := utf8.EncodeRune(buf[:], r)
+
+	return append(dst, buf[:n]...)
+}
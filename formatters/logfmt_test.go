@@ -0,0 +1,120 @@
+package formatters
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type logfmtTest struct {
+	record   *Record
+	expected string
+}
+
+func TestLogfmt(t *testing.T) {
+
+	timestamp := Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339}
+
+	tests := []logfmtTest{
+		{
+			&Record{Timestamp: timestamp, Level: 4, LogLevel: "INFO", Variables: []interface{}{"Test string", 123, 4.5, true}, Tags: []string{"tag1", "tag2"}},
+			`ts=2006-01-02T15:04:05Z level=info tags=tag1,tag2 msg="Test string 123 4.5 true"`,
+		},
+		{
+			&Record{Timestamp: timestamp, Level: 2, LogLevel: "ERROR", Variables: []interface{}{"failed", Field{"user id", `a"b`}, Field{"query", "a=b\nc"}, Field{"count", 3}}},
+			`ts=2006-01-02T15:04:05Z level=error msg=failed user_id="a\"b" query="a=b\nc" count=3`,
+		},
+		{
+			&Record{Timestamp: timestamp, Level: 4, LogLevel: "INFO", Template: "user {user} logged in", Variables: []interface{}{Field{"user", "alice"}}},
+			`ts=2006-01-02T15:04:05Z level=info msg="user alice logged in" template="user {user} logged in" user=alice`,
+		},
+		{
+			&Record{Timestamp: timestamp, Level: 5, LogLevel: "DEBUG", Variables: []interface{}{Field{"empty", ""}}},
+			`ts=2006-01-02T15:04:05Z level=debug msg="" empty=""`,
+		},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Call the function
+		b, err := Logfmt.Format(nil, test.record, &RenderContext{})
+		if err != nil {
+			t.Error(err)
+		}
+
+		// Check if the output is correct
+		if output := string(b); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, test.expected, output)
+		}
+	}
+}
+
+type parseLogfmtTest struct {
+	line     string
+	expected []Field
+	err      bool
+}
+
+func TestParseLogfmt(t *testing.T) {
+
+	tests := []parseLogfmtTest{
+		{
+			`ts=2006-01-02T15:04:05Z level=info tags=tag1,tag2 msg="Test string 123"`,
+			[]Field{{"ts", "2006-01-02T15:04:05Z"}, {"level", "info"}, {"tags", "tag1,tag2"}, {"msg", "Test string 123"}},
+			false,
+		},
+		{
+			`msg="a\"b\\c\nd\u001b" debug empty=""`,
+			[]Field{{"msg", "a\"b\\c\nd\x1b"}, {"debug", ""}, {"empty", ""}},
+			false,
+		},
+		{`msg="unterminated`, nil, true},
+		{`msg="bad \x escape"`, nil, true},
+		{`=value`, nil, true},
+		{`key=a"b`, nil, true},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Call the function
+		fields, err := ParseLogfmt(test.line)
+		if (err != nil) != test.err {
+			t.Errorf("[%d] Incorrect error. Expected error: %t, received '%v'", i, test.err, err)
+			continue
+		}
+
+		// Check if the output is correct
+		if !reflect.DeepEqual(fields, test.expected) {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%v'\n\tReceived: '%v'", i, test.expected, fields)
+		}
+	}
+}
+
+func TestLogfmtRoundTrip(t *testing.T) {
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Variables: []interface{}{"multi\nline \"quoted\" \x1b[31mvalue", Field{"path", `C:\temp`}},
+	}
+
+	// Format and parse the log
+	b, err := Logfmt.Format(nil, record, &RenderContext{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields, err := ParseLogfmt(string(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check if the values survived
+	expected := []Field{{"ts", "2006-01-02T15:04:05Z"}, {"level", "info"}, {"msg", "multi\nline \"quoted\" \x1b[31mvalue"}, {"path", `C:\temp`}}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expected, fields)
+	}
+}
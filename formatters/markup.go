@@ -0,0 +1,184 @@
+package formatters
+
+import "strings"
+
+// The maximum number of markup tags that can be open at once. Any deeper tags are removed.
+const maxMarkupDepth = 8
+
+// A MarkupResolver will return the style for the name of a markup tag (e.g. "green" in "<green>OK</green>").
+// If the name is not recognised, false is returned and the tag is written as it is.
+type MarkupResolver func(name string) (Style, bool)
+
+// Markup is a message that was written with a format string (e.g. by `plog.Infof`) when markup is enabled.
+// The parts alternate between text from the format string, which can contain markup tags, and the formatted values, which are always written literally.
+// Markup is only read from these messages and from message templates, so user data such as "<red>" is never treated as a tag.
+type Markup []string
+
+// String will return the message with any markup tags left as they are.
+func (m Markup) String() string {
+	return strings.Join(m, "")
+}
+
+// A markup replaces the markup tags in a message with styles, or removes them if the message is not being colored.
+// Tags can be written as "<name>...</name>" or "{name}...{/}". Both "</>" and "{/}" close the most recent tag.
+// Brackets can be escaped with a backslash (e.g. "\<green>") to write them literally.
+type markup struct {
+	resolve MarkupResolver
+	render  bool                  // Whether or not tags are replaced by styles
+	base    Style                 // The style that surrounds the message, which is restored when tags are closed
+	stack   [maxMarkupDepth]Style // The styles of the tags that are currently open
+	depth   int
+}
+
+// newMarkup will return a markup for the render context, or nil if markup is disabled.
+// If render is false, tags are always removed.
+func newMarkup(ctx *RenderContext, render bool, base Style) *markup {
+	if ctx.Markup == nil {
+		return nil
+	}
+	return &markup{
+		resolve: ctx.Markup,
+		render:  render,
+		base:    base,
+	}
+}
+
+// append will append s to dst, replacing any markup tags.
+// The text between the tags is appended using appendText.
+func (m *markup) append(dst []byte, s string, appendText func(dst []byte, s string) []byte) []byte {
+
+	// Most messages don't contain any markup
+	if strings.IndexAny(s, "<{\\") < 0 {
+		return appendText(dst, s)
+	}
+
+	var start int
+
+	for i := 0; i < len(s); i++ {
+
+		switch c := s[i]; c {
+
+		// Escaped brackets are written literally
+		case '\\':
+			if i+1 < len(s) && strings.IndexByte("<>{}", s[i+1]) >= 0 {
+				dst = appendText(dst, s[start:i])
+				start = i + 1
+				i++
+			}
+
+		// Tags
+		case '<', '{':
+
+			// Double braces are never tags
+			if c == '{' && i+1 < len(s) && s[i+1] == '{' {
+				i++
+				continue
+			}
+
+			// Find the end of the tag
+			end := byte('>')
+			if c == '{' {
+				end = '}'
+			}
+			j := strings.IndexByte(s[i+1:], end)
+			if j < 0 {
+				continue
+			}
+
+			// Apply the tag if it is valid
+			if next, ok := m.tag(appendText(dst, s[start:i]), s[i+1:i+1+j]); ok {
+				dst = next
+				i += j + 1
+				start = i + 1
+			}
+		}
+	}
+
+	return appendText(dst, s[start:])
+}
+
+// tag will apply the tag with the given name and return whether or not the tag was valid.
+// Valid tags are always removed, but are only replaced by a style when rendering.
+func (m *markup) tag(dst []byte, name string) ([]byte, bool) {
+
+	// Closing tags
+	if strings.HasPrefix(name, "/") {
+		if name != "/" {
+			if _, ok := m.resolve(name[1:]); !ok {
+				return dst, false
+			}
+		}
+		if m.depth == 0 {
+			return dst, true
+		}
+		m.depth--
+		return m.restore(dst), true
+	}
+
+	// Opening tags
+	style, ok := m.resolve(name)
+	if !ok {
+		return dst, false
+	}
+	if m.depth == maxMarkupDepth {
+		return dst, true
+	}
+	m.stack[m.depth] = style
+	m.depth++
+
+	if m.render {
+		dst = append(dst, style...)
+	}
+
+	return dst, true
+}
+
+// restore will reset the text attributes and then apply the base style and any tags that are still open.
+func (m *markup) restore(dst []byte) []byte {
+
+	if !m.render {
+		return dst
+	}
+
+	dst = append(dst, reset...)
+	dst = append(dst, m.base...)
+	for _, style := range m.stack[:m.depth] {
+		dst = append(dst, style...)
+	}
+
+	return dst
+}
+
+// close will close any tags that are still open.
+func (m *markup) close(dst []byte) []byte {
+
+	if m.depth == 0 {
+		return dst
+	}
+	m.depth = 0
+
+	return m.restore(dst)
+}
+
+// appendMarkup will append a message with markup to dst, replacing the tags in the parts that come from the format string.
+// Any tags that are still open are closed at the end of the message.
+func (s sanitizer) appendMarkup(dst []byte, message Markup) []byte {
+
+	if s.markup == nil {
+		for _, part := range message {
+			dst = s.appendText(dst, part)
+		}
+		return dst
+	}
+
+	// Even parts come from the format string and odd parts are values
+	for i, part := range message {
+		if i%2 == 0 {
+			dst = s.markup.append(dst, part, s.appendText)
+		} else {
+			dst = s.appendText(dst, part)
+		}
+	}
+
+	return s.markup.close(dst)
+}
@@ -0,0 +1,117 @@
+package formatters
+
+import (
+	"testing"
+	"time"
+)
+
+// testMarkup resolves a few markup tags for testing.
+func testMarkup(name string) (Style, bool) {
+	switch name {
+	case "red":
+		return "\x1b[31m", true
+	case "bold":
+		return "\x1b[1m", true
+	}
+	return "", false
+}
+
+type markupTest struct {
+	input    Markup
+	render   string
+	stripped string
+}
+
+func TestMarkup(t *testing.T) {
+
+	tests := []markupTest{
+		{Markup{"no markup"}, "no markup", "no markup"},
+		{Markup{"status <red>FAIL</red>"}, "status \x1b[31mFAIL\x1b[0m", "status FAIL"},
+		{Markup{"{bold}bold{/} text"}, "\x1b[1mbold\x1b[0m text", "bold text"},
+		{Markup{"<bold>a <red>b</> c</bold>"}, "\x1b[1ma \x1b[31mb\x1b[0m\x1b[1m c\x1b[0m", "a b c"},
+		{Markup{"<red>unclosed"}, "\x1b[31munclosed\x1b[0m", "unclosed"},
+		{Markup{"stray</red> close{/}"}, "stray close", "stray close"},
+		{Markup{"literal \\<red>x\\</red> \\{bold}"}, "literal <red>x</red> {bold}", "literal <red>x</red> {bold}"},
+		{Markup{"<div> {user} a < b > c {{red}}"}, "<div> {user} a < b > c {{red}}", "<div> {user} a < b > c {{red}}"},
+		{Markup{"<red>", "<bold>a<b>c{/}", "</red>"}, "\x1b[31m<bold>a<b>c{/}\x1b[0m", "<bold>a<b>c{/}"},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		for _, render := range []bool{true, false} {
+
+			// Call the function, markup should only be read from the format string and never from values or other strings
+			expected := test.stripped + " <red>string</red>"
+			if render {
+				expected = test.render + " <red>string</red>"
+			}
+			b, err := PlainRecord.Format(nil, &Record{Variables: []interface{}{test.input, "<red>string</red>"}}, &RenderContext{ColorLogging: render, Markup: testMarkup})
+			if err != nil {
+				t.Error(err)
+			}
+
+			// Check if the output is correct
+			if output := string(b); output != expected {
+				t.Errorf("[%d] Incorrect output. Expected '%q', received '%q'", i, expected, output)
+			}
+		}
+	}
+}
+
+func TestTextMarkup(t *testing.T) {
+
+	// Expected output
+	const expected = "2006-01-02T15:04:05Z [INFO] \x1b[1muser \x1b[31m<alice>\x1b[0m\x1b[1m logged in key={/} \x1b[31m<red>\x1b[0m\x1b[0m"
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Template:  "user <red>{user}</red> logged in",
+		Variables: []interface{}{Field{"user", "<alice>"}, Field{"key", "{/}"}, Colored("\x1b[31m<red>\x1b[0m")},
+	}
+	ctx := &RenderContext{
+		ColorLogging: true,
+		MessageStyle: "\x1b[1m",
+		Markup:       testMarkup,
+	}
+
+	// Call the function, markup in the template should span the placeholder but values should never be treated as markup
+	b, err := TextRecord.Format(nil, record, ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if output := string(b); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expected, output)
+	}
+}
+
+func TestJSONMarkup(t *testing.T) {
+
+	// Expected output
+	const expected = `{"timestamp":"2006-01-02T15:04:05Z","logLevel":"INFO","template":"user \u003cred\u003e{user}\u003c/red\u003e","message":"user alice","variables":[{"key":"user","value":"alice"},"OK","\u003cbold\u003e"]}`
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Template:  "user <red>{user}</red>",
+		Variables: []interface{}{Field{"user", "alice"}, Markup{"<bold>OK</bold>"}, "<bold>"},
+	}
+
+	// Call the function, JSON should strip markup even when color logging is enabled
+	b, err := JSONRecord.Format(nil, record, &RenderContext{ColorLogging: true, Markup: testMarkup})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if output := string(b); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
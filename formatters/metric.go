@@ -0,0 +1,29 @@
+package formatters
+
+import "strconv"
+
+// A Metric is a named measurement that can be passed to any logging function alongside the other variables.
+// Formatters that support metrics (e.g. `NewEMF`) publish them. Other formatters display them as 'name=value'.
+type Metric struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit,omitempty"`
+}
+
+// String will stringify the metric into the format 'name=value'.
+func (metric Metric) String() string {
+	return metric.Name + "=" + strconv.FormatFloat(metric.Value, 'g', -1, 64)
+}
+
+// asField will return a variable as a field if it is a field or a metric.
+// Structured formatters use this to write metrics alongside fields rather than in the message.
+func asField(variable interface{}) (Field, bool) {
+	switch v := variable.(type) {
+	case Field:
+		return v, true
+	case Metric:
+		return Field{Key: v.Name, Value: v.Value}, true
+	default:
+		return Field{}, false
+	}
+}
@@ -1,19 +1,17 @@
 package formatters
 
-import (
-	"fmt"
-	"strings"
-)
-
 // Plain will print a plain text string.
+// Loggers use PlainRecord instead (See `plog.WithFormatter`).
 func Plain(timestamp, logLevel string, variables []interface{}, tags []string) (string, error) {
+	return formatStrings(plain, timestamp, logLevel, variables, tags)
+}
 
-	strVariables := make([]string, len(variables))
-
-	// Loop through the variables and format them
-	for i, variable := range variables {
-		strVariables[i] = fmt.Sprintf("%v", variable)
-	}
+// PlainRecord will print the variables of a record as a plain text string.
+var PlainRecord = Func(plain)
 
-	return fmt.Sprintf("%s", strings.Join(strVariables, " ")), nil
+// plain will print the variables as a plain text string.
+func plain(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+	s := newSanitizer(ctx, false)
+	s.markup = newMarkup(ctx, ctx.ColorLogging, "")
+	return s.appendVariables(dst, record.Variables), nil
 }
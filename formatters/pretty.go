@@ -0,0 +1,287 @@
+package formatters
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// PrettyTimestamp dictates how the pretty formatter renders timestamps.
+type PrettyTimestamp int
+
+// Available pretty timestamps:
+const (
+	// ClockTimestamp renders the time of day with milliseconds (e.g. "15:04:05.000")
+	ClockTimestamp PrettyTimestamp = iota
+	// RelativeTimestamp renders the time elapsed since the logger was created (e.g. "+1.234s")
+	RelativeTimestamp
+	// FullTimestamp renders the timestamp using the logger's timestamp settings
+	FullTimestamp
+	// NoTimestamp does not render a timestamp
+	NoTimestamp
+)
+
+// The default widths of the pretty formatter's columns.
+const (
+	defaultPrettyTagWidth     = 12
+	defaultPrettyMessageWidth = 40
+	prettyLevelWidth          = 5
+	prettyRelativeWidth       = 9
+)
+
+// The style used for tags, fields and timestamps that are not styled by the theme.
+const faint Style = "\x1b[2m"
+
+// Pretty will format a log into aligned columns for reading in a terminal during development.
+// Use `NewPretty` to change the timestamp or the widths of the columns.
+var Pretty = NewPretty()
+
+//
+// Structures
+//
+
+// pretty holds the settings of a pretty formatter.
+type pretty struct {
+	timestamp    PrettyTimestamp
+	tagWidth     int
+	messageWidth int
+}
+
+// A PrettyOption is a function that sets an option on a pretty formatter.
+type PrettyOption func(p *pretty)
+
+//
+// Constructors
+//
+
+// NewPretty will create a formatter that formats logs into aligned columns (e.g. `15:04:05.000 INFO  #api         Test string   user=alice`).
+// Log levels are padded to the same width and tags are dimmed and padded to a column. Fields are written after the message in a muted color
+// and the message is padded so that the fields line up. Any lines after the first line of a multi-line value are indented to the start of the message.
+// Widths ignore escape sequences, so strings colored with `plog.Color` are aligned correctly.
+func NewPretty(opts ...PrettyOption) Func {
+
+	p := &pretty{
+		timestamp:    ClockTimestamp,
+		tagWidth:     defaultPrettyTagWidth,
+		messageWidth: defaultPrettyMessageWidth,
+	}
+
+	// Apply the custom options
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p.format
+}
+
+//
+// Functional Options
+//
+
+// WithPrettyTimestamp will return a function that sets how the pretty formatter renders timestamps.
+func WithPrettyTimestamp(timestamp PrettyTimestamp) PrettyOption {
+	return func(p *pretty) {
+		p.timestamp = timestamp
+	}
+}
+
+// WithTagWidth will return a function that sets the width of the tag column. Longer tags are not truncated.
+// If the width is 0, tags are not padded.
+func WithTagWidth(width int) PrettyOption {
+	return func(p *pretty) {
+		p.tagWidth = width
+	}
+}
+
+// WithMessageWidth will return a function that sets the width that messages are padded to when they are followed by fields.
+// If the width is 0, messages are not padded.
+func WithMessageWidth(width int) PrettyOption {
+	return func(p *pretty) {
+		p.messageWidth = width
+	}
+}
+
+//
+// Instance methods
+//
+
+// format will format a log into aligned columns.
+func (p *pretty) format(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+
+	// If the whole line is styled, render the line first and then style it
+	if ctx.LineStyle != "" {
+		n := len(dst)
+		dst = p.line(dst, record, ctx)
+		dst, newLines := trimNewLines(dst, n)
+		dst = appendLineStyle(dst, n, ctx.LineStyle)
+		return appendNewLines(dst, newLines), nil
+	}
+
+	return p.line(dst, record, ctx), nil
+}
+
+// line will format a log into aligned columns without the line style.
+func (p *pretty) line(dst []byte, record *Record, ctx *RenderContext) []byte {
+
+	n := len(dst)
+
+	// Muted parts are dimmed unless the theme styles them
+	muted := Style("")
+	if ctx.ColorLogging {
+		muted = faint
+	}
+
+	// Add the timestamp
+	if p.timestamp != NoTimestamp {
+		style := ctx.TimestampStyle
+		if style == "" {
+			style = muted
+		}
+		dst = style.AppendStart(dst)
+		dst = p.appendTimestamp(dst, record.Timestamp)
+		dst = style.AppendEnd(dst)
+		dst = append(dst, ' ')
+	}
+
+	// Add the log level, padded to the width of the longest level
+	dst = ctx.LogLevelStyle.Append(dst, record.LogLevel)
+	dst = appendPadding(dst, prettyLevelWidth-len(record.LogLevel))
+	dst = append(dst, ' ')
+
+	// Add the tags
+	if len(record.Tags) > 0 || p.tagWidth > 0 {
+		m := len(dst)
+		t := newSanitizer(ctx, false)
+		for i, tag := range record.Tags {
+			if i > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = t.appendTag(dst, muted+ctx.TagStyle(i), tag)
+		}
+		dst = appendPadding(dst, p.tagWidth-VisibleWidth(string(dst[m:])))
+		dst = append(dst, ' ')
+	}
+
+	// Continuation lines are indented to the start of the message
+	indent := VisibleWidth(string(dst[n:]))
+
+	// Add the message. Fields are written after the message
+	m := len(dst)
+	s := newSanitizer(ctx, true)
+	if s.indent != "" {
+		s.indent = string(appendPadding(nil, indent))
+	}
+	s.markup = newMarkup(ctx, ctx.ColorLogging, ctx.MessageStyle)
+	dst = ctx.MessageStyle.AppendStart(dst)
+	variables := record.Variables
+	if record.Template != "" {
+		var used int
+		dst, used = appendTemplate(dst, record.Template, variables, s)
+		variables = variables[used:]
+	}
+	first := record.Template == ""
+	hasFields := false
+	for _, variable := range variables {
+		if _, ok := asField(variable); ok {
+			hasFields = true
+			continue
+		}
+		if !first {
+			dst = append(dst, ' ')
+		}
+		first = false
+		if message, ok := variable.(Markup); ok {
+			dst = s.appendMarkup(dst, message)
+		} else {
+			dst = s.appendValue(dst, variable)
+		}
+	}
+	dst, newLines := trimNewLines(dst, m)
+	dst = ctx.MessageStyle.AppendEnd(dst)
+
+	// Add the fields in a muted color, lining them up if the last line of the message is short enough
+	if hasFields {
+		last := bytes.LastIndexByte(dst[m:], '\n') + 1
+		dst = appendPadding(dst, p.messageWidth-VisibleWidth(string(dst[m+last:])))
+		for _, variable := range variables {
+			if field, ok := asField(variable); ok {
+				dst = append(dst, ' ')
+				dst = muted.AppendStart(dst)
+				dst = s.appendValue(dst, field)
+				dst = muted.AppendEnd(dst)
+			}
+		}
+	}
+
+	// If newlines were kept, indent the lines after the first
+	if !s.enabled {
+		dst = indentLines(dst, m, indent)
+	}
+
+	return appendNewLines(dst, newLines)
+}
+
+// appendTimestamp will append the timestamp to dst.
+func (p *pretty) appendTimestamp(dst []byte, timestamp Timestamp) []byte {
+	switch p.timestamp {
+	case RelativeTimestamp:
+		m := len(dst)
+		dst = append(dst, '+')
+		dst = strconv.AppendFloat(dst, timestamp.Elapsed.Seconds(), 'f', 3, 64)
+		dst = append(dst, 's')
+		return leftPad(dst, m, prettyRelativeWidth)
+	case FullTimestamp:
+		return timestamp.Append(dst)
+	default:
+		return timestamp.Time.AppendFormat(dst, "15:04:05.000")
+	}
+}
+
+// appendPadding will append the given number of spaces to dst.
+func appendPadding(dst []byte, n int) []byte {
+	for i := 0; i < n; i++ {
+		dst = append(dst, ' ')
+	}
+	return dst
+}
+
+// leftPad will add spaces before the text that starts at dst[m] until it is the given width.
+func leftPad(dst []byte, m, width int) []byte {
+
+	padding := width - (len(dst) - m)
+	if padding <= 0 {
+		return dst
+	}
+
+	dst = appendPadding(dst, padding)
+	copy(dst[m+padding:], dst[m:len(dst)-padding])
+	for i := m; i < m+padding; i++ {
+		dst[i] = ' '
+	}
+
+	return dst
+}
+
+// indentLines will indent each line after the first line of the text that starts at dst[m].
+func indentLines(dst []byte, m, indent int) []byte {
+
+	lines := 0
+	for _, c := range dst[m:] {
+		if c == '\n' {
+			lines++
+		}
+	}
+	if lines == 0 || indent == 0 {
+		return dst
+	}
+
+	// Build the indented text after the original text and then move it into place
+	end := len(dst)
+	for i := m; i < end; i++ {
+		dst = append(dst, dst[i])
+		if dst[i] == '\n' {
+			dst = appendPadding(dst, indent)
+		}
+	}
+
+	return append(dst[:m], dst[end:]...)
+}
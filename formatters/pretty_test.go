@@ -0,0 +1,93 @@
+package formatters
+
+import (
+	"testing"
+	"time"
+)
+
+type prettyTest struct {
+	formatter Func
+	record    *Record
+	ctx       *RenderContext
+	expected  string
+}
+
+func TestPretty(t *testing.T) {
+
+	timestamp := Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 123456789, time.UTC), Format: time.RFC3339, Elapsed: 1234 * time.Millisecond}
+	colorCtx := &RenderContext{ColorLogging: true, LogLevelStyle: "\x1b[32m", TagStyles: []Style{"\x1b[34m"}}
+
+	tests := []prettyTest{
+		{
+			Pretty,
+			&Record{Timestamp: timestamp, Level: 4, LogLevel: "INFO", Variables: []interface{}{"Test string", 123, Field{"user", "alice"}, Field{"n", 1}}, Tags: []string{"tag1"}},
+			&RenderContext{},
+			"15:04:05.123 INFO  #tag1        Test string 123                          user=alice n=1",
+		},
+		{
+			Pretty,
+			&Record{Timestamp: timestamp, Level: 5, LogLevel: "DEBUG", Variables: []interface{}{"first line\nsecond line", Field{"sql", "SELECT *\nFROM users"}}},
+			&RenderContext{},
+			"15:04:05.123 DEBUG              first line\n" +
+				"                                second line                              sql=SELECT *\n" +
+				"                                FROM users",
+		},
+		{
+			Pretty,
+			&Record{Timestamp: timestamp, Level: 3, LogLevel: "WARN", Variables: []interface{}{"done\n"}, Tags: []string{"a", "b"}},
+			&RenderContext{},
+			"15:04:05.123 WARN  #a #b        done\n",
+		},
+		{
+			NewPretty(WithPrettyTimestamp(RelativeTimestamp), WithTagWidth(0), WithMessageWidth(0)),
+			&Record{Timestamp: timestamp, Level: 4, LogLevel: "INFO", Template: "user {user} logged in", Variables: []interface{}{Field{"user", "alice"}, Field{"ip", "10.0.0.1"}}},
+			&RenderContext{},
+			"  +1.234s INFO  user alice logged in ip=10.0.0.1",
+		},
+		{
+			NewPretty(WithPrettyTimestamp(NoTimestamp), WithMessageWidth(20)),
+			&Record{Timestamp: timestamp, Level: 4, LogLevel: "INFO", Variables: []interface{}{"\x1b[31mred\x1b[0m", "text", Field{"user", "alice"}}, Tags: []string{"tag1"}},
+			colorCtx,
+			"\x1b[32mINFO\x1b[0m  \x1b[2m\x1b[34m#tag1\x1b[0m        \x1b[31mred\x1b[0m text             \x1b[2muser=alice\x1b[0m",
+		},
+		{
+			NewPretty(WithPrettyTimestamp(FullTimestamp), WithTagWidth(0)),
+			&Record{Timestamp: timestamp, Level: 2, LogLevel: "ERROR", Variables: []interface{}{"line one\nline two\x1b[2J"}},
+			&RenderContext{Sanitize: true, Multiline: true},
+			"2006-01-02T15:04:05Z ERROR line one\n                           line two",
+		},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Call the function
+		b, err := test.formatter.Format(nil, test.record, test.ctx)
+		if err != nil {
+			t.Error(err)
+		}
+
+		// Check if the output is correct
+		if output := string(b); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", i, test.expected, output)
+		}
+	}
+}
+
+func TestVisibleWidth(t *testing.T) {
+
+	// Escape sequences should not be counted
+	tests := map[string]int{
+		"":                                   0,
+		"INFO":                               4,
+		"\x1b[32mINFO\x1b[0m":                4,
+		"ünïcödé":                            7,
+		"\x1b]0;title\a\x1b[1;31mred\x1b[0m": 3,
+	}
+
+	for s, expected := range tests {
+		if width := VisibleWidth(s); width != expected {
+			t.Errorf("Incorrect output. Expected %d for '%q', received %d", expected, s, width)
+		}
+	}
+}
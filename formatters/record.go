@@ -0,0 +1,44 @@
+package formatters
+
+// A Record holds all the data of a single log in a structured, uncolored form.
+type Record struct {
+	Timestamp Timestamp     // The raw time of the log along with the logger's timestamp settings
+	Level     int           // The numeric log level (1 = FATAL ... 6 = TRACE)
+	LogLevel  string        // The uncolored name of the log level (e.g. "INFO")
+	Template  string        // The message template, if the log was written with a template (e.g. "user {user} logged in")
+	Variables []interface{} // Every variable passed to the logging function, including fields
+	Err       error         // The first error passed to the logging function (e.g. `plog.Error`), even if it was formatted into the message
+	Tags      []string      // The uncolored tags
+	Caller    Caller        // The location of the code that wrote the log, if the logger records callers
+}
+
+// A Caller is the location of the code that wrote a log.
+// The zero value means that the location is unknown.
+type Caller struct {
+	File     string // The full path of the source file
+	Line     int    // The line number in the source file
+	Function string // The fully qualified name of the function (e.g. "main.run")
+}
+
+// Message will render the message template with its values.
+// If the log was not written with a template, the variables are rendered instead.
+func (record *Record) Message() string {
+	if record.Template == "" {
+		return string(appendVariables(nil, record.Variables))
+	}
+	dst, _ := AppendTemplate(nil, record.Template, record.Variables)
+	return string(dst)
+}
+
+// Fields will return any variables that are fields (key/value pairs).
+func (record *Record) Fields() (fields []Field) {
+
+	// Loop through the variables and pick out the fields
+	for _, variable := range record.Variables {
+		if field, ok := variable.(Field); ok {
+			fields = append(fields, field)
+		}
+	}
+
+	return
+}
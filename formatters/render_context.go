@@ -0,0 +1,33 @@
+package formatters
+
+import "time"
+
+// A RenderContext holds the logger settings that a formatter may need when rendering a record.
+// Styles are only set when color logging is enabled, so formatters can apply them unconditionally.
+type RenderContext struct {
+	ColorLogging       bool              // Whether or not the logger has color logging enabled
+	LogLevelStyle      Style             // The style for the record's log level (from the log level color map)
+	TagStyles          []Style           // The style for each of the record's tags (from the tag color map)
+	LogLevelColorMap   map[int]Style     // The style for each numeric log level in the logger's log level color map
+	TagColorMap        map[string]Style  // The style for each tag in the logger's tag color map
+	TimestampStyle     Style             // The style for the timestamp (from the theme)
+	MessageStyle       Style             // The style for the message body (from the theme)
+	SeparatorStyle     Style             // The style for the brackets around the log level and tags (from the theme)
+	LineStyle          Style             // The style for the entire line (from the theme)
+	TimestampFormat    string            // The logger's timestamp format (See `time.Time.Format`)
+	TimestampLocation  *time.Location    // The time zone that the logger renders timestamps in, or nil if timestamps are not converted
+	TimestampPrecision time.Duration     // The duration that the logger truncates timestamps to, or 0 if timestamps are not truncated
+	TimestampEncoding  TimestampEncoding // How the logger encodes timestamps
+	Sanitize           bool              // Whether or not control characters and foreign escape sequences should be removed from variables
+	Multiline          bool              // Whether or not multi-line variables should be rendered as indented continuation lines
+	Markup             MarkupResolver    // If set, markup tags in templates and `Markup` messages are rendered (or stripped when the formatter doesn't use color)
+	StyleResolver      MarkupResolver    // Resolves attribute names (e.g. "bold,red") into styles when color logging is enabled
+}
+
+// TagStyle will return the style for the tag at the given index.
+func (ctx *RenderContext) TagStyle(i int) Style {
+	if i < len(ctx.TagStyles) {
+		return ctx.TagStyles[i]
+	}
+	return ""
+}
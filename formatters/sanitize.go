@@ -0,0 +1,257 @@
+package formatters
+
+import "unicode/utf8"
+
+// The indentation used for continuation lines when multi-line values are rendered.
+const continuationIndent = "    "
+
+// Colored is a string that has been colored by plog (See `plog.Color`).
+// Sanitization strips escape sequences from any other variable, but the colors of these strings are kept if color logging is enabled.
+type Colored string
+
+// A sanitizer decides how variables are made safe to write to line-based outputs.
+// It also renders any markup in messages written with a format string, since the markup's styles must not be sanitized.
+type sanitizer struct {
+	enabled   bool    // Whether or not control characters are escaped
+	stripANSI bool    // Whether or not escape sequences are removed instead of escaped
+	colors    bool    // Whether or not the colors of Colored strings are kept
+	indent    string  // If set, newlines are kept and followed by this indentation
+	markup    *markup // If set, markup tags in format strings and templates are replaced
+}
+
+// newSanitizer will return the sanitizer for the given render context.
+// Multi-line rendering is only used if it is allowed by the formatter.
+func newSanitizer(ctx *RenderContext, multiline bool) sanitizer {
+	s := sanitizer{
+		enabled:   ctx.Sanitize,
+		stripANSI: ctx.Sanitize,
+		colors:    ctx.ColorLogging,
+	}
+	if multiline && ctx.Multiline {
+		s.indent = continuationIndent
+	}
+	return s
+}
+
+// appendVariables will append each variable to dst, separated by spaces.
+func (s sanitizer) appendVariables(dst []byte, variables []interface{}) []byte {
+
+	if !s.enabled && s.markup == nil {
+		return appendVariables(dst, variables)
+	}
+
+	// Loop through the variables and format them
+	for i, variable := range variables {
+		if i > 0 {
+			dst = append(dst, ' ')
+		}
+
+		// Only format strings can contain markup. Other variables (including strings and fields) are data, so are never treated as markup
+		if message, ok := variable.(Markup); ok {
+			dst = s.appendMarkup(dst, message)
+			continue
+		}
+
+		dst = s.appendValue(dst, variable)
+	}
+
+	return dst
+}
+
+// appendTag will append a tag to dst with a '#' prefix, wrapped in the given style.
+// The tag is sanitized in the same way as variables so that it cannot forge log lines.
+func (s sanitizer) appendTag(dst []byte, style Style, tag string) []byte {
+	dst = style.AppendStart(dst)
+	dst = append(dst, '#')
+	dst = s.appendText(dst, tag)
+	return style.AppendEnd(dst)
+}
+
+// appendText will append a string to dst and sanitize it if necessary.
+func (s sanitizer) appendText(dst []byte, str string) []byte {
+	if !s.enabled {
+		return append(dst, str...)
+	}
+	return s.appendString(dst, str)
+}
+
+// appendValue will append a variable to dst and sanitize it if necessary.
+func (s sanitizer) appendValue(dst []byte, value interface{}) []byte {
+
+	if !s.enabled {
+		return appendValue(dst, value)
+	}
+
+	switch v := value.(type) {
+	case Colored:
+		return s.appendEscaped(dst, string(v), s.colors)
+	case Field:
+		dst = s.appendString(dst, v.Key)
+		dst = append(dst, '=')
+		return s.appendValue(dst, v.Value)
+	}
+
+	// Format the value and only sanitize it if it contains anything unsafe
+	n := len(dst)
+	dst = appendValue(dst, value)
+	if !needsSanitizing(dst[n:]) {
+		return dst
+	}
+
+	return s.appendString(dst[:n], string(dst[n:]))
+}
+
+// appendString will append a string to dst with any control characters escaped.
+// If the sanitizer strips ANSI, escape sequences are removed entirely.
+// If the sanitizer has an indent, newlines are kept and each new line is indented.
+func (s sanitizer) appendString(dst []byte, str string) []byte {
+	return s.appendEscaped(dst, str, false)
+}
+
+// appendEscaped will append a string to dst in the same way as appendString.
+// If keepSGR is true, escape sequences that only set colors and text attributes are kept.
+func (s sanitizer) appendEscaped(dst []byte, str string, keepSGR bool) []byte {
+
+	for i := 0; i < len(str); {
+
+		c := str[i]
+
+		// Escape sequences
+		if c == 0x1b {
+			end := skipEscapeSequence(str, i)
+			if keepSGR && isSGR(str[i:end]) {
+				dst = append(dst, str[i:end]...)
+				i = end
+				continue
+			}
+			if s.stripANSI {
+				i = end
+				continue
+			}
+		}
+
+		// Newlines
+		if c == '\n' && s.indent != "" {
+			dst = append(dst, '\n')
+			dst = append(dst, s.indent...)
+			i++
+			continue
+		}
+
+		// Other ASCII control characters (tabs are left alone)
+		if c < 0x20 && c != '\t' || c == 0x7f {
+			dst = appendEscapedControl(dst, rune(c))
+			i++
+			continue
+		}
+
+		// Unicode control characters and invalid UTF-8
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(str[i:])
+			if r >= 0x80 && r <= 0x9f {
+				dst = appendEscapedControl(dst, r)
+			} else if r == utf8.RuneError && size == 1 {
+				dst = append(dst, "\uFFFD"...)
+			} else {
+				dst = append(dst, str[i:i+size]...)
+			}
+			i += size
+			continue
+		}
+
+		dst = append(dst, c)
+		i++
+	}
+
+	return dst
+}
+
+// appendEscapedControl will append a control character to dst as a readable escape code.
+func appendEscapedControl(dst []byte, r rune) []byte {
+	switch r {
+	case '\n':
+		return append(dst, `\n`...)
+	case '\r':
+		return append(dst, `\r`...)
+	case '\b':
+		return append(dst, `\b`...)
+	case '\f':
+		return append(dst, `\f`...)
+	case '\v':
+		return append(dst, `\v`...)
+	case '\a':
+		return append(dst, `\a`...)
+	}
+	if r < 0x80 {
+		return append(dst, '\\', 'x', hex[r>>4], hex[r&0xf])
+	}
+	return append(dst, '\\', 'u', '0', '0', hex[r>>4], hex[r&0xf])
+}
+
+// needsSanitizing will return whether or not the given bytes contain any control characters.
+func needsSanitizing(b []byte) bool {
+	for i, c := range b {
+		if c < 0x20 && c != '\t' || c == 0x7f {
+			return true
+		}
+		// C1 control characters are encoded as 0xc2 0x80-0x9f
+		if c == 0xc2 && i+1 < len(b) && b[i+1] >= 0x80 && b[i+1] <= 0x9f {
+			return true
+		}
+		if c >= utf8.RuneSelf && !utf8.Valid(b[i:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSGR will return whether or not the given escape sequence only sets text attributes (e.g. "\x1b[31;1m").
+// Other escape sequences can move the cursor or change the terminal, so are never kept.
+func isSGR(sequence string) bool {
+	if len(sequence) < 3 || sequence[1] != '[' || sequence[len(sequence)-1] != 'm' {
+		return false
+	}
+	for i := 2; i < len(sequence)-1; i++ {
+		if (sequence[i] < '0' || sequence[i] > '9') && sequence[i] != ';' {
+			return false
+		}
+	}
+	return true
+}
+
+// skipEscapeSequence will return the index after the escape sequence that starts at i.
+// CSI sequences (e.g. colors and cursor movement), OSC sequences (e.g. window titles) and two-character sequences are recognised.
+func skipEscapeSequence(s string, i int) int {
+
+	i++
+	if i >= len(s) {
+		return i
+	}
+
+	switch s[i] {
+
+	// Control Sequence Introducer: parameters and intermediates followed by a final byte
+	case '[':
+		for i++; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+		return i
+
+	// Operating System Command: terminated by BEL or ST (ESC \)
+	case ']':
+		for i++; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == 0x1b && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return i
+
+	default:
+		return i + 1
+	}
+}
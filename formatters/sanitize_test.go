@@ -0,0 +1,127 @@
+package formatters
+
+import (
+	"testing"
+	"time"
+)
+
+type sanitizeTest struct {
+	ctx      RenderContext
+	input    interface{}
+	expected string
+}
+
+func TestSanitize(t *testing.T) {
+
+	tests := []sanitizeTest{
+		{RenderContext{}, "line1\nline2", "line1\nline2"},
+		{RenderContext{Sanitize: true}, "safe\tstring", "safe\tstring"},
+		{RenderContext{Sanitize: true}, "line1\nfake [ERROR] line", `line1\nfake [ERROR] line`},
+		{RenderContext{Sanitize: true}, "a\r\x00b\x7f\u0085", `a\r\x00b\x7f\u0085`},
+		{RenderContext{Sanitize: true}, "\x1b[31mred\x1b[0m \x1b[2J\x1b]0;title\a\x1bcdone", "red done"},
+		{RenderContext{Sanitize: true}, "invalid \xff utf8", "invalid � utf8"},
+		{RenderContext{Sanitize: true, ColorLogging: true}, "\x1b[41mFORGED\x1b[0m", "FORGED"},
+		{RenderContext{Sanitize: true, ColorLogging: true}, Colored("\x1b[31mred\x1b[0m"), "\x1b[31mred\x1b[0m"},
+		{RenderContext{Sanitize: true, ColorLogging: true}, Colored("\x1b[31mred"), "\x1b[31mred"},
+		{RenderContext{Sanitize: true, ColorLogging: true}, Colored("\x1b[31mred\nfake\x1b[2J\x1b[1m bold\x1b[0m"), "\x1b[31mred\\nfake\x1b[1m bold\x1b[0m"},
+		{RenderContext{Sanitize: true, ColorLogging: true}, Field{"key\n", Colored("\x1b[31mred\x1b[0m")}, "key\\n=\x1b[31mred\x1b[0m"},
+		{RenderContext{Sanitize: true}, Colored("\x1b[31mred\x1b[0m"), "red"},
+		{RenderContext{}, Colored("\x1b[31mred\x1b[0m"), "\x1b[31mred\x1b[0m"},
+		{RenderContext{Sanitize: true}, []string{"a\nb"}, `[a\nb]`},
+		{RenderContext{Sanitize: true, Multiline: true}, "line1\nline2\r\n", `line1\nline2\r\n`},
+		{RenderContext{Multiline: true}, "\x1b[2J", "\x1b[2J"},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Call the function
+		b, err := PlainRecord.Format(nil, &Record{Variables: []interface{}{test.input}}, &test.ctx)
+		if err != nil {
+			t.Error(err)
+		}
+
+		// Check if the output is correct
+		if output := string(b); output != test.expected {
+			t.Errorf("[%d] Incorrect output. Expected '%q', received '%q'", i, test.expected, output)
+		}
+	}
+}
+
+func TestTextMultiline(t *testing.T) {
+
+	// Expected output
+	const expected = "2006-01-02T15:04:05Z [ERROR] request failed: panic: oops\n    goroutine 1 [running]:\n    main.main()"
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     2,
+		LogLevel:  "ERROR",
+		Template:  "request failed: {error}",
+		Variables: []interface{}{Field{"error", "panic: oops\ngoroutine 1 [running]:\nmain.main()"}},
+	}
+
+	// Call the function
+	b, err := TextRecord.Format(nil, record, &RenderContext{Sanitize: true, Multiline: true})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if output := string(b); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestCSVSanitize(t *testing.T) {
+
+	// Expected output
+	const expected = `2006-01-02T15:04:05Z,INFO,line1\nline2 \x1b[31mred,tag1:tag2\nfake`
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Variables: []interface{}{"line1\nline2", "\x1b[31mred"},
+		Tags:      []string{"tag1", "tag2\nfake"},
+	}
+
+	// Call the function, CSV should escape newlines even when the logger isn't sanitizing and ignore multi-line rendering
+	b, err := CSVRecord.Format(nil, record, &RenderContext{Multiline: true})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if output := string(b); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestTextSanitizeTags(t *testing.T) {
+
+	// Expected output
+	const expected = "2006-01-02T15:04:05Z [INFO] [\x1b[31m#tag1\\nfake [ERROR] line\x1b[0m #tag2] Test string"
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Variables: []interface{}{"Test string"},
+		Tags:      []string{"tag1\nfake [ERROR] line", "tag2\x1b[2J"},
+	}
+
+	// Call the function, tags should be sanitized inside their styles
+	b, err := TextRecord.Format(nil, record, &RenderContext{Sanitize: true, Multiline: true, TagStyles: []Style{"\x1b[31m"}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if output := string(b); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expected, output)
+	}
+}
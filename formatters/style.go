@@ -0,0 +1,70 @@
+package formatters
+
+import "unicode/utf8"
+
+// The SGR escape sequence that removes all text attributes.
+const reset = "\x1b[0m"
+
+// A Style is a pre-rendered SGR escape sequence (e.g. "\x1b[31;4m") used to color text.
+// An empty style leaves text unchanged.
+type Style string
+
+// Apply will wrap the given text in the style.
+func (style Style) Apply(s string) string {
+	if style == "" {
+		return s
+	}
+	return string(style) + s + reset
+}
+
+// Append will append the given text to dst, wrapped in the style.
+func (style Style) Append(dst []byte, s string) []byte {
+	if style == "" {
+		return append(dst, s...)
+	}
+	dst = append(dst, style...)
+	dst = append(dst, s...)
+	return append(dst, reset...)
+}
+
+// AppendPrefixed will append the prefix and the given text to dst, both wrapped in the style.
+func (style Style) AppendPrefixed(dst []byte, prefix byte, s string) []byte {
+	if style == "" {
+		return append(append(dst, prefix), s...)
+	}
+	dst = append(dst, style...)
+	dst = append(dst, prefix)
+	dst = append(dst, s...)
+	return append(dst, reset...)
+}
+
+// AppendStart will append the escape sequence that starts the style to dst.
+// This can be used to style text that is appended directly to dst (e.g. timestamps).
+func (style Style) AppendStart(dst []byte) []byte {
+	return append(dst, style...)
+}
+
+// AppendEnd will append the escape sequence that ends the style to dst.
+func (style Style) AppendEnd(dst []byte) []byte {
+	if style == "" {
+		return dst
+	}
+	return append(dst, reset...)
+}
+
+// VisibleWidth will return the number of characters in s that take up space on a terminal.
+// Escape sequences (e.g. the colors added by styles or `plog.Color`) are not counted.
+func VisibleWidth(s string) (width int) {
+
+	for i := 0; i < len(s); {
+		if s[i] == 0x1b {
+			i = skipEscapeSequence(s, i)
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		i += size
+		width++
+	}
+
+	return width
+}
@@ -0,0 +1,339 @@
+package formatters
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Facility is the syslog facility that a log is sent from.
+type Facility int
+
+// Syslog facilities:
+const (
+	FacilityKern Facility = iota
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLPR
+	FacilityNews
+	FacilityUUCP
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFTP
+)
+
+// Local syslog facilities:
+const (
+	FacilityLocal0 Facility = iota + 16
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// The maximum length of structured data parameter names.
+const maxSyslogNameLength = 32
+
+// RFC5424 will format a log into an RFC 5424 syslog message from the user facility.
+// Use `NewRFC5424` to set the facility, app name, hostname, process ID or enterprise ID.
+var RFC5424 = NewRFC5424()
+
+// RFC3164 will format a log into a legacy RFC 3164 (BSD) syslog message from the user facility.
+// Use `NewRFC3164` to set the facility, app name, hostname or process ID.
+var RFC3164 = NewRFC3164()
+
+//
+// Structures
+//
+
+// syslog holds the header values of a syslog formatter.
+type syslog struct {
+	facility     Facility
+	appName      string
+	hostname     string
+	procID       string
+	enterpriseID string
+	tagsID       string // The start of the structured data element for tags (e.g. `[tags@12345`)
+	fieldsID     string // The start of the structured data element for fields (e.g. `[fields@12345`)
+}
+
+// A SyslogOption is a function that sets an option on a syslog formatter.
+type SyslogOption func(s *syslog)
+
+//
+// Constructors
+//
+
+// NewRFC5424 will create a formatter that formats logs into RFC 5424 syslog messages.
+// Structured data IDs must include an enterprise ID, so tags and fields are only written as structured data if one is set (See `WithEnterpriseID`).
+// Otherwise, tags are added before the message and fields are added after it.
+// By default, the facility is user, the app name is the name of the program and the hostname and process ID are detected.
+func NewRFC5424(opts ...SyslogOption) Func {
+	s := newSyslog(opts...)
+	return s.rfc5424
+}
+
+// NewRFC3164 will create a formatter that formats logs into legacy RFC 3164 (BSD) syslog messages.
+// Tags are added before the message and fields are added after it.
+// By default, the facility is user, the app name is the name of the program and the hostname and process ID are detected.
+func NewRFC3164(opts ...SyslogOption) Func {
+	s := newSyslog(opts...)
+	return s.rfc3164
+}
+
+// newSyslog will create the header values of a syslog formatter.
+func newSyslog(opts ...SyslogOption) *syslog {
+
+	// Create the default header values
+	s := &syslog{
+		facility: FacilityUser,
+		appName:  filepath.Base(os.Args[0]),
+		hostname: hostname(),
+		procID:   strconv.Itoa(os.Getpid()),
+	}
+
+	// Apply the custom options
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	// Create the structured data IDs
+	if s.enterpriseID != "" {
+		s.tagsID = "[" + string(appendSyslogParamName(nil, "tags@"+s.enterpriseID))
+		s.fieldsID = "[" + string(appendSyslogParamName(nil, "fields@"+s.enterpriseID))
+	}
+
+	return s
+}
+
+//
+// Functional Options
+//
+
+// WithFacility will return a function that sets the facility of syslog messages.
+func WithFacility(facility Facility) SyslogOption {
+	return func(s *syslog) {
+		s.facility = facility
+	}
+}
+
+// WithAppName will return a function that sets the app name of syslog messages.
+func WithAppName(appName string) SyslogOption {
+	return func(s *syslog) {
+		s.appName = appName
+	}
+}
+
+// WithHostname will return a function that sets the hostname of syslog messages.
+func WithHostname(hostname string) SyslogOption {
+	return func(s *syslog) {
+		s.hostname = hostname
+	}
+}
+
+// WithProcID will return a function that sets the process ID of syslog messages.
+func WithProcID(procID string) SyslogOption {
+	return func(s *syslog) {
+		s.procID = procID
+	}
+}
+
+// WithEnterpriseID will return a function that sets the private enterprise number (PEN) used in structured data IDs.
+// This should be the number that IANA assigned to your organisation (e.g. "12345" gives `tags@12345` and `fields@12345`).
+// It is only used by RFC 5424 messages.
+func WithEnterpriseID(enterpriseID string) SyslogOption {
+	return func(s *syslog) {
+		s.enterpriseID = enterpriseID
+	}
+}
+
+//
+// Instance methods
+//
+
+// rfc5424 will format a log into an RFC 5424 syslog message.
+// e.g. `<14>1 2006-01-02T15:04:05Z host app 123 - [tags@12345 tag="tag1"][fields@12345 user="alice"] Test string`
+// Without an enterprise ID: `<14>1 2006-01-02T15:04:05Z host app 123 - - [#tag1] Test string user=alice`
+func (s *syslog) rfc5424(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+
+	// Add the header
+	dst = s.appendPriority(dst, record.Level)
+	dst = append(dst, '1', ' ')
+	dst = record.Timestamp.Time.Truncate(time.Microsecond).AppendFormat(dst, time.RFC3339Nano)
+	dst = append(dst, ' ')
+	dst = appendSyslogHeaderValue(dst, s.hostname, 255)
+	dst = append(dst, ' ')
+	dst = appendSyslogHeaderValue(dst, s.appName, 48)
+	dst = append(dst, ' ')
+	dst = appendSyslogHeaderValue(dst, s.procID, 128)
+	dst = append(dst, ' ', '-', ' ')
+
+	// Without an enterprise ID, the tags and fields are written with the message
+	if s.enterpriseID == "" {
+		dst = append(dst, '-')
+		return s.appendText(dst, record, ctx), nil
+	}
+
+	// Add the tags and fields as structured data
+	n := len(dst)
+	if len(record.Tags) > 0 {
+		dst = append(dst, s.tagsID...)
+		for _, tag := range record.Tags {
+			dst = append(dst, ` tag="`...)
+			dst = appendSyslogParamValue(dst, tag)
+			dst = append(dst, '"')
+		}
+		dst = append(dst, ']')
+	}
+	fields := false
+	for _, variable := range record.Variables {
+		if field, ok := asField(variable); ok {
+			if !fields {
+				dst = append(dst, s.fieldsID...)
+				fields = true
+			}
+			dst = append(dst, ' ')
+			dst = appendSyslogParamName(dst, field.Key)
+			dst = append(dst, '=', '"')
+			dst = appendSyslogParamValue(dst, string(appendValue(nil, field.Value)))
+			dst = append(dst, '"')
+		}
+	}
+	if fields {
+		dst = append(dst, ']')
+	}
+	if len(dst) == n {
+		dst = append(dst, '-')
+	}
+
+	// Add the message. Markup is always removed
+	m := len(dst)
+	dst = append(dst, ' ')
+	if dst = appendMessage(dst, record, sanitizer{markup: newMarkup(ctx, false, "")}); len(dst) == m+1 {
+		dst = dst[:m]
+	}
+
+	return dst, nil
+}
+
+// rfc3164 will format a log into a legacy RFC 3164 (BSD) syslog message.
+// e.g. `<14>Jan  2 15:04:05 host app[123]: [#tag1] Test string user=alice`
+func (s *syslog) rfc3164(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+
+	// Add the header
+	dst = s.appendPriority(dst, record.Level)
+	dst = record.Timestamp.Time.AppendFormat(dst, time.Stamp)
+	dst = append(dst, ' ')
+	dst = appendSyslogHeaderValue(dst, s.hostname, 255)
+	dst = append(dst, ' ')
+	dst = appendSyslogHeaderValue(dst, s.appName, 32)
+	if s.procID != "" {
+		dst = append(dst, '[')
+		dst = append(dst, s.procID...)
+		dst = append(dst, ']')
+	}
+	dst = append(dst, ':')
+
+	return s.appendText(dst, record, ctx), nil
+}
+
+// appendText will append the tags, message and fields of a log to dst, each preceded by a space.
+// e.g. ` [#tag1] Test string user=alice`
+func (s *syslog) appendText(dst []byte, record *Record, ctx *RenderContext) []byte {
+
+	// Add the tags
+	for _, tag := range record.Tags {
+		dst = append(dst, " [#"...)
+		dst = append(dst, tag...)
+		dst = append(dst, ']')
+	}
+
+	// Add the message. Markup is always removed
+	m := len(dst)
+	dst = append(dst, ' ')
+	if dst = appendMessage(dst, record, sanitizer{markup: newMarkup(ctx, false, "")}); len(dst) == m+1 {
+		dst = dst[:m]
+	}
+
+	// Add the fields
+	for _, variable := range record.Variables {
+		if field, ok := asField(variable); ok {
+			dst = append(dst, ' ')
+			dst = appendValue(dst, field)
+		}
+	}
+
+	return dst
+}
+
+// appendPriority will append the priority of a log to dst (e.g. `<14>`).
+func (s *syslog) appendPriority(dst []byte, level int) []byte {
+	dst = append(dst, '<')
+	dst = strconv.AppendInt(dst, int64(int(s.facility)*8+SyslogSeverity(level)), 10)
+	return append(dst, '>')
+}
+
+// appendSyslogHeaderValue will append a header value to dst.
+// Header values can only contain printable ASCII characters and are limited in length. Empty values are written as '-'.
+func appendSyslogHeaderValue(dst []byte, value string, maxLength int) []byte {
+
+	if value == "" {
+		return append(dst, '-')
+	}
+	if len(value) > maxLength {
+		value = value[:maxLength]
+	}
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c <= ' ' || c >= 0x7f {
+			c = '_'
+		}
+		dst = append(dst, c)
+	}
+
+	return dst
+}
+
+// appendSyslogParamName will append the name of a structured data parameter to dst.
+// Names can only contain printable ASCII characters other than '=', ']' and '"'. Any other characters are replaced with underscores.
+func appendSyslogParamName(dst []byte, name string) []byte {
+
+	if name == "" {
+		return append(dst, '_')
+	}
+	if len(name) > maxSyslogNameLength {
+		name = name[:maxSyslogNameLength]
+	}
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c <= ' ' || c >= 0x7f || c == '=' || c == ']' || c == '"' {
+			c = '_'
+		}
+		dst = append(dst, c)
+	}
+
+	return dst
+}
+
+// appendSyslogParamValue will append the value of a structured data parameter to dst.
+// The characters '"', '\' and ']' are escaped with a backslash.
+func appendSyslogParamValue(dst []byte, value string) []byte {
+
+	for i := 0; i < len(value); i++ {
+		if c := value[i]; c == '"' || c == '\\' || c == ']' {
+			dst = append(dst, '\\')
+		}
+		dst = append(dst, value[i])
+	}
+
+	return dst
+}
@@ -0,0 +1,69 @@
+package formatters
+
+import (
+	"testing"
+	"time"
+)
+
+type syslogTest struct {
+	formatter Func
+	record    *Record
+	expected  string
+}
+
+func TestSyslog(t *testing.T) {
+
+	timestamp := Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 123456789, time.UTC), Format: time.RFC3339}
+	opts := []SyslogOption{WithHostname("host1"), WithAppName("app"), WithProcID("123")}
+	rfc5424 := NewRFC5424(opts...)
+	rfc3164 := NewRFC3164(opts...)
+	structured := NewRFC5424(append(opts, WithEnterpriseID("12345"))...)
+
+	tests := []syslogTest{
+		{
+			rfc5424,
+			&Record{Timestamp: timestamp, Level: 4, LogLevel: "INFO", Variables: []interface{}{"Test string", 123}},
+			`<14>1 2006-01-02T15:04:05.123456Z host1 app 123 - - Test string 123`,
+		},
+		{
+			structured,
+			&Record{Timestamp: timestamp, Level: 2, LogLevel: "ERROR", Variables: []interface{}{"failed", Field{"user", `a"b]`}, Field{"a=b", 1}}, Tags: []string{"tag1", "tag2"}},
+			`<11>1 2006-01-02T15:04:05.123456Z host1 app 123 - [tags@12345 tag="tag1" tag="tag2"][fields@12345 user="a\"b\]" a_b="1"] failed`,
+		},
+		{
+			NewRFC5424(WithFacility(FacilityLocal0), WithHostname(""), WithAppName("my app"), WithProcID(""), WithEnterpriseID("12345")),
+			&Record{Timestamp: timestamp, Level: 1, LogLevel: "FATAL", Variables: []interface{}{Field{"user", "alice"}}},
+			`<130>1 2006-01-02T15:04:05.123456Z - my_app - - [fields@12345 user="alice"]`,
+		},
+		{
+			rfc5424,
+			&Record{Timestamp: timestamp, Level: 2, LogLevel: "ERROR", Variables: []interface{}{"failed", Field{"user", "alice"}}, Tags: []string{"tag1", "tag2"}},
+			`<11>1 2006-01-02T15:04:05.123456Z host1 app 123 - - [#tag1] [#tag2] failed user=alice`,
+		},
+		{
+			rfc3164,
+			&Record{Timestamp: timestamp, Level: 3, LogLevel: "WARN", Variables: []interface{}{"Test string", Field{"user", "alice"}}, Tags: []string{"tag1"}},
+			`<12>Jan  2 15:04:05 host1 app[123]: [#tag1] Test string user=alice`,
+		},
+		{
+			NewRFC3164(WithFacility(FacilityDaemon), WithHostname("host1"), WithAppName("app"), WithProcID("")),
+			&Record{Timestamp: timestamp, Level: 6, LogLevel: "TRACE", Template: "user {user} logged in", Variables: []interface{}{Field{"user", "alice"}}},
+			`<31>Jan  2 15:04:05 host1 app: user alice logged in user=alice`,
+		},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Call the function
+		b, err := test.formatter.Format(nil, test.record, &RenderContext{})
+		if err != nil {
+			t.Error(err)
+		}
+
+		// Check if the output is correct
+		if output := string(b); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, test.expected, output)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package formatters
+
+// Message templates use named placeholders which are surrounded by braces (e.g. "user {user} logged in").
+// Literal braces can be written by doubling them (e.g. "{{" and "}}").
+
+// Placeholders will return the names of the placeholders in a message template in the order that they appear.
+func Placeholders(template string) (names []string) {
+
+	// Loop through the placeholders and collect their names
+	for i := 0; ; {
+		start, end := nextPlaceholder(template, i)
+		if start < 0 {
+			return
+		}
+		names = append(names, template[start+1:end-1])
+		i = end
+	}
+}
+
+// AppendTemplate will append a message template to dst with each placeholder replaced by a value.
+// The values are taken in order from the start of the variables. Fields are replaced by their value.
+// Placeholders without a matching variable are left as they are.
+// The number of variables that were used is returned so that any remaining variables can be rendered separately.
+func AppendTemplate(dst []byte, template string, variables []interface{}) ([]byte, int) {
+	return appendTemplate(dst, template, variables, sanitizer{})
+}
+
+// appendTemplate will append a message template to dst with each placeholder replaced by a sanitized value.
+func appendTemplate(dst []byte, template string, variables []interface{}, s sanitizer) ([]byte, int) {
+
+	var n int
+
+	// Loop through the placeholders and replace them with values
+	for i := 0; ; {
+		start, end := nextPlaceholder(template, i)
+		if start < 0 {
+			return s.appendLiteral(dst, template[i:], true), n
+		}
+
+		dst = s.appendLiteral(dst, template[i:start], false)
+
+		if n < len(variables) {
+			value := variables[n]
+			if field, ok := value.(Field); ok {
+				value = field.Value
+			}
+			dst = s.appendValue(dst, value)
+			n++
+		} else {
+			dst = append(dst, template[start:end]...)
+		}
+
+		i = end
+	}
+}
+
+// nextPlaceholder will return the start and end index of the next placeholder in the template, starting at i.
+// If there are no more placeholders, start will be -1.
+func nextPlaceholder(template string, i int) (start, end int) {
+
+	for ; i < len(template); i++ {
+
+		if template[i] != '{' {
+			continue
+		}
+
+		// Skip escaped braces
+		if i+1 < len(template) && template[i+1] == '{' {
+			i++
+			continue
+		}
+
+		// Find the end of the name
+		j := i + 1
+		for j < len(template) && isPlaceholderChar(template[j]) {
+			j++
+		}
+		if j > i+1 && j < len(template) && template[j] == '}' {
+			return i, j + 1
+		}
+	}
+
+	return -1, -1
+}
+
+// isPlaceholderChar will return whether or not the given character can be used in a placeholder name.
+func isPlaceholderChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-'
+}
+
+// appendUnescaped will append the literal text of a template to dst, replacing doubled braces with single braces.
+func appendUnescaped(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		dst = append(dst, s[i])
+		if (s[i] == '{' || s[i] == '}') && i+1 < len(s) && s[i+1] == s[i] {
+			i++
+		}
+	}
+	return dst
+}
+
+// appendLiteral will append the literal text of a template to dst and render any markup.
+// Markup tags can span placeholders, so any tags that are still open are only closed at the end of the template.
+func (s sanitizer) appendLiteral(dst []byte, text string, last bool) []byte {
+
+	if s.markup == nil {
+		return appendUnescaped(dst, text)
+	}
+
+	dst = s.markup.append(dst, text, appendUnescaped)
+	if last {
+		dst = s.markup.close(dst)
+	}
+
+	return dst
+}
@@ -0,0 +1,47 @@
+package formatters
+
+import (
+	"reflect"
+	"testing"
+)
+
+type templateTest struct {
+	template  string
+	variables []interface{}
+	expected  string
+	n         int
+}
+
+func TestPlaceholders(t *testing.T) {
+
+	// Expected output
+	expected := []string{"user", "ip", "request.id"}
+
+	// Call the function
+	output := Placeholders("user {user} logged in from {ip} {{escaped}} { not a placeholder } {request.id}")
+
+	// Check if the output is correct
+	if !reflect.DeepEqual(output, expected) {
+		t.Errorf("Incorrect output.\n\tExpected: '%v'\n\tReceived: '%v'", expected, output)
+	}
+}
+
+func TestAppendTemplate(t *testing.T) {
+
+	tests := []templateTest{
+		{"no placeholders", nil, "no placeholders", 0},
+		{"user {user} logged in from {ip}", []interface{}{Field{"user", "alice"}, Field{"ip", "10.0.0.1"}}, "user alice logged in from 10.0.0.1", 2},
+		{"user {user} logged in from {ip}", []interface{}{"alice"}, "user alice logged in from {ip}", 1},
+		{"{count} items", []interface{}{Field{"count", 3}, "extra"}, "3 items", 1},
+		{"{{literal}} {value}}}", []interface{}{Field{"value", true}}, "{literal} true}", 1},
+		{"{} {a b}", []interface{}{"unused"}, "{} {a b}", 0},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+		b, n := AppendTemplate(nil, test.template, test.variables)
+		if output := string(b); output != test.expected || n != test.n {
+			t.Errorf("[%d] Incorrect output. Expected '%s' (%d), received '%s' (%d)", i, test.expected, test.n, output, n)
+		}
+	}
+}
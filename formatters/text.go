@@ -1,48 +1,129 @@
 package formatters
 
-import (
-	"fmt"
-	"regexp"
-	"strings"
-)
+import "bytes"
 
 // Text will format a log into a human-readable string.
+// Loggers use TextRecord instead, which receives the uncolored log level and tags (See `plog.WithFormatter`).
 func Text(timestamp, logLevel string, variables []interface{}, tags []string) (string, error) {
+	return formatStrings(text, timestamp, logLevel, variables, tags)
+}
 
-	// TODO: Should the color formatting happen here?
+// TextRecord will format a record into a human-readable string.
+var TextRecord = Func(text)
 
-	strVariables := make([]string, len(variables))
-	var tagsStr string
+// text will format a log into a human-readable string.
+func text(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
 
-	// Loop through the variables and format them
-	for i, variable := range variables {
-		strVariables[i] = fmt.Sprintf("%v", variable)
+	// If the whole line is styled, render the line first and then style it
+	if ctx.LineStyle != "" {
+		n := len(dst)
+		dst, err := textLine(dst, record, ctx)
+		if err != nil {
+			return dst[:n], err
+		}
+		dst, newLines := trimNewLines(dst, n)
+		dst = appendLineStyle(dst, n, ctx.LineStyle)
+		return appendNewLines(dst, newLines), nil
 	}
 
-	// Create a regular expression to check for color in the tags
-	re := regexp.MustCompile("^(\\x1b\\[\\d{1,2}(?:;\\d{1,2})*m)(.*)(\\x1b\\[0m)$")
+	return textLine(dst, record, ctx)
+}
 
-	// TODO: There is probably a better way to put the tag inside the color formatting
+// textLine will format a log into a human-readable string without the line style.
+func textLine(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
 
-	// Loop through the tags and format them
-	for i := range tags {
+	// Add the timestamp and log level
+	dst = ctx.TimestampStyle.AppendStart(dst)
+	dst = record.Timestamp.Append(dst)
+	dst = ctx.TimestampStyle.AppendEnd(dst)
+	dst = append(dst, ' ')
+	dst = ctx.SeparatorStyle.Append(dst, "[")
+	dst = ctx.LogLevelStyle.Append(dst, record.LogLevel)
+	dst = ctx.SeparatorStyle.Append(dst, "]")
+	dst = append(dst, ' ')
 
-		// Find out if the tag has color formatting
-		matches := re.FindAllStringSubmatch(tags[i], -1)
+	// If there are tags, add them to the output
+	if len(record.Tags) > 0 {
+		dst = ctx.SeparatorStyle.Append(dst, "[")
 
-		if len(matches) > 0 {
-			// Add a '#' INSIDE the color formatting string
-			tags[i] = fmt.Sprintf("%s#%s%s", matches[0][1], matches[0][2], matches[0][3])
-		} else {
-			// Add a '#'
-			tags[i] = fmt.Sprintf("#%s", tags[i])
+		// Loop through the tags and add a '#' inside the color formatting
+		t := newSanitizer(ctx, false)
+		for i, tag := range record.Tags {
+			if i > 0 {
+				dst = append(dst, ' ')
+			}
+			dst = t.appendTag(dst, ctx.TagStyle(i), tag)
 		}
+
+		dst = ctx.SeparatorStyle.Append(dst, "]")
+		dst = append(dst, ' ')
 	}
 
-	// If there are tags, add them to the output
-	if len(tags) > 0 {
-		tagsStr = fmt.Sprintf("[%s] ", strings.Join(tags, " "))
+	// Multi-line variables are rendered as indented continuation lines if enabled
+	s := newSanitizer(ctx, true)
+	s.markup = newMarkup(ctx, ctx.ColorLogging, ctx.MessageStyle)
+	n := len(dst)
+	dst = ctx.MessageStyle.AppendStart(dst)
+
+	// If the log has a template, render the message and then any remaining variables
+	if record.Template != "" {
+		var used int
+		dst, used = appendTemplate(dst, record.Template, record.Variables, s)
+		if used < len(record.Variables) {
+			dst = append(dst, ' ')
+			dst = s.appendVariables(dst, record.Variables[used:])
+		}
+		return endMessageStyle(dst, n, ctx.MessageStyle), nil
 	}
 
-	return fmt.Sprintf("%s [%s] %s%s", timestamp, logLevel, tagsStr, strings.Join(strVariables, " ")), nil
+	dst = s.appendVariables(dst, record.Variables)
+
+	return endMessageStyle(dst, n, ctx.MessageStyle), nil
+}
+
+// appendLineStyle will style the line that starts at dst[n].
+// Other styles in the line end with a reset, so the line style is applied again after each reset.
+func appendLineStyle(dst []byte, n int, style Style) []byte {
+
+	// Build the styled line after the original line and then move it into place
+	m := len(dst)
+	dst = style.AppendStart(dst)
+	for line := dst[n:m]; len(line) > 0; {
+		i := bytes.Index(line, []byte(reset))
+		if i < 0 {
+			dst = append(dst, line...)
+			break
+		}
+		dst = append(dst, line[:i+len(reset)]...)
+		dst = style.AppendStart(dst)
+		line = line[i+len(reset):]
+	}
+	dst = style.AppendEnd(dst)
+
+	return append(dst[:n], dst[m:]...)
+}
+
+// endMessageStyle will end the style of the message that starts at dst[n].
+// Messages from the formatted logging functions usually end with a new line, which is kept outside of the style so that it doesn't color the next line.
+func endMessageStyle(dst []byte, n int, style Style) []byte {
+	dst, newLines := trimNewLines(dst, n)
+	dst = style.AppendEnd(dst)
+	return appendNewLines(dst, newLines)
+}
+
+// trimNewLines will remove any new lines from the end of dst (but not before dst[n]) and return how many were removed.
+func trimNewLines(dst []byte, n int) ([]byte, int) {
+	end := len(dst)
+	for end > n && dst[end-1] == '\n' {
+		end--
+	}
+	return dst[:end], len(dst) - end
+}
+
+// appendNewLines will append the given number of new lines to dst.
+func appendNewLines(dst []byte, newLines int) []byte {
+	for i := 0; i < newLines; i++ {
+		dst = append(dst, '\n')
+	}
+	return dst
 }
@@ -0,0 +1,272 @@
+package formatters
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+//
+// Structures
+//
+
+// TemplateData is the data that a template formatter executes its template with.
+// Styles are only applied when color logging is enabled, so templates work for terminals and files.
+type TemplateData struct {
+	Timestamp string        // The rendered timestamp
+	Time      time.Time     // The raw time of the log
+	Level     string        // The log level, styled with the log level color map
+	RawLevel  string        // The uncolored log level (e.g. "INFO")
+	Tags      []string      // The tags, each styled with the tag color map
+	RawTags   []string      // The uncolored tags
+	Message   string        // The message, rendered from the template or from the variables that are not fields
+	Variables []interface{} // Every variable passed to the logging function, including fields
+	Fields    []Field       // The fields (and metrics) passed to the logging function
+	Caller    Caller        // The location of the code that wrote the log, if the logger records callers
+}
+
+// A textTemplate executes a parsed template for each log.
+// Templates are cloned so that the helper functions can use each log's render context.
+type textTemplate struct {
+	pool sync.Pool
+}
+
+// A templateInstance is a clone of a template with helper functions bound to the current render context.
+type templateInstance struct {
+	template *template.Template
+	ctx      *RenderContext
+	buffer   appendWriter
+}
+
+// An appendWriter appends everything written to it to a byte slice.
+type appendWriter struct {
+	dst []byte
+}
+
+//
+// Constructors
+//
+
+// Template will create a formatter that renders each log with the given `text/template` template (e.g. `{{.Timestamp}} {{pad 5 .Level}} {{.Message}}`).
+// The template is executed with `TemplateData` and can use the helper functions `pad`, `upper`, `lower`, `join`, `color` and `json`:
+//   - `pad 5 .Level` pads a value with spaces to a width, ignoring escape sequences. Negative widths pad on the left
+//   - `upper .RawLevel` and `lower .RawLevel` change the case of a value
+//   - `join "," .RawTags` joins the values of a list
+//   - `color "bold,red" .Message` styles a value using attribute names (See `plog.ParseAttribute`) when color logging is enabled
+//   - `json .Fields` encodes a value as JSON. Values that cannot be encoded are written as strings
+//
+// Any error in the template is returned immediately, rather than when the first log is written.
+func Template(tmpl string) (Func, error) {
+
+	// Parse the template once with placeholder helpers
+	parsed, err := template.New("plog").Funcs(templateFuncs(nil)).Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &textTemplate{}
+	t.pool.New = func() interface{} {
+		instance := &templateInstance{}
+		instance.template = template.Must(parsed.Clone()).Funcs(templateFuncs(instance))
+		return instance
+	}
+
+	return t.format, nil
+}
+
+// MustTemplate is like Template, but panics if the template cannot be parsed.
+// It can be used to create a formatter in a variable declaration.
+func MustTemplate(tmpl string) Func {
+	formatter, err := Template(tmpl)
+	if err != nil {
+		panic(err)
+	}
+	return formatter
+}
+
+//
+// Instance methods
+//
+
+// format will render a log with the template.
+func (t *textTemplate) format(dst []byte, record *Record, ctx *RenderContext) ([]byte, error) {
+
+	instance := t.pool.Get().(*templateInstance)
+	defer t.pool.Put(instance)
+	instance.ctx = ctx
+	defer func() { instance.ctx = nil }()
+
+	// Execute the template, appending directly to dst
+	n := len(dst)
+	instance.buffer.dst = dst
+	err := instance.template.Execute(&instance.buffer, newTemplateData(record, ctx))
+	dst, instance.buffer.dst = instance.buffer.dst, nil
+	if err != nil {
+		return dst[:n], err
+	}
+
+	return dst, nil
+}
+
+// Write will append p to the writer's byte slice.
+func (w *appendWriter) Write(p []byte) (int, error) {
+	w.dst = append(w.dst, p...)
+	return len(p), nil
+}
+
+// Field will return the value of the first field with the given key, or nil if there isn't one (e.g. `{{.Field "user"}}`).
+func (data TemplateData) Field(key string) interface{} {
+	for _, field := range data.Fields {
+		if field.Key == key {
+			return field.Value
+		}
+	}
+	return nil
+}
+
+// newTemplateData will create the data that a template is executed with.
+func newTemplateData(record *Record, ctx *RenderContext) TemplateData {
+
+	data := TemplateData{
+		Timestamp: record.Timestamp.String(),
+		Time:      record.Timestamp.Time,
+		Level:     ctx.LogLevelStyle.Apply(record.LogLevel),
+		RawLevel:  record.LogLevel,
+		RawTags:   record.Tags,
+		Variables: record.Variables,
+		Caller:    record.Caller,
+	}
+
+	// Style the tags
+	data.Tags = make([]string, len(record.Tags))
+	for i, tag := range record.Tags {
+		data.Tags[i] = ctx.TagStyle(i).Apply(tag)
+	}
+
+	// Render the message. Markup is rendered when color logging is enabled and removed otherwise
+	s := newSanitizer(ctx, true)
+	s.markup = newMarkup(ctx, ctx.ColorLogging, "")
+	data.Message = string(appendMessage(nil, record, s))
+
+	// Collect the fields
+	for _, variable := range record.Variables {
+		if field, ok := asField(variable); ok {
+			data.Fields = append(data.Fields, field)
+		}
+	}
+
+	return data
+}
+
+// templateFuncs will return the helper functions for a template instance.
+// The instance is nil when the template is first parsed, since only the names of the functions are needed.
+func templateFuncs(instance *templateInstance) template.FuncMap {
+	return template.FuncMap{
+		"pad":   pad,
+		"upper": func(value interface{}) string { return changeCase(toString(value), strings.ToUpper) },
+		"lower": func(value interface{}) string { return changeCase(toString(value), strings.ToLower) },
+		"join":  join,
+		"json":  toJSON,
+		"color": func(attributes string, value interface{}) string {
+			if instance == nil || instance.ctx == nil || instance.ctx.StyleResolver == nil {
+				return toString(value)
+			}
+			style, ok := instance.ctx.StyleResolver(attributes)
+			if !ok {
+				return toString(value)
+			}
+			return style.Apply(toString(value))
+		},
+	}
+}
+
+// pad will pad a value with spaces until it is the given width. Escape sequences are not counted.
+// A negative width pads the value on the left.
+func pad(width int, value interface{}) string {
+
+	str := toString(value)
+	left := width < 0
+	if left {
+		width = -width
+	}
+
+	padding := width - VisibleWidth(str)
+	if padding <= 0 {
+		return str
+	}
+	if left {
+		return strings.Repeat(" ", padding) + str
+	}
+
+	return str + strings.Repeat(" ", padding)
+}
+
+// changeCase will change the case of a string without changing any escape sequences in it.
+func changeCase(s string, change func(string) string) string {
+
+	var dst []byte
+	start := 0
+
+	// Loop through the string and change the case of the text between escape sequences
+	for i := 0; i < len(s); {
+		if s[i] != 0x1b {
+			i++
+			continue
+		}
+		dst = append(dst, change(s[start:i])...)
+		start, i = i, skipEscapeSequence(s, i)
+		dst = append(dst, s[start:i]...)
+		start = i
+	}
+
+	return string(append(dst, change(s[start:])...))
+}
+
+// join will join the values of a list (e.g. tags or fields) with a separator.
+func join(separator string, list interface{}) string {
+
+	value := reflect.ValueOf(list)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return toString(list)
+	}
+
+	var dst []byte
+	for i := 0; i < value.Len(); i++ {
+		if i > 0 {
+			dst = append(dst, separator...)
+		}
+		dst = appendValue(dst, value.Index(i).Interface())
+	}
+
+	return string(dst)
+}
+
+// toJSON will encode a value as JSON. Values that cannot be encoded are written as JSON strings.
+func toJSON(value interface{}) string {
+
+	if err, ok := value.(error); ok && err != nil {
+		return string(appendJSONString(nil, err.Error()))
+	}
+
+	dst, err := appendJSONValue(nil, value)
+	if err != nil {
+		return string(appendJSONString(nil, toString(value)))
+	}
+
+	return string(dst)
+}
+
+// toString will convert a value into a string in the same format as `fmt.Sprint(value)`.
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return string(appendValue(nil, value))
+	}
+}
@@ -0,0 +1,107 @@
+package formatters
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type textTemplateTest struct {
+	template string
+	ctx      *RenderContext
+	expected string
+}
+
+func TestTemplate(t *testing.T) {
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Variables: []interface{}{Markup{"Test <bold>string</bold>"}, 123, Field{"user", "alice"}, Field{"err", errors.New("failed")}},
+		Tags:      []string{"tag1", "tag2"},
+		Caller:    Caller{File: "/src/app/main.go", Line: 12, Function: "main.run"},
+	}
+
+	// Resolve a couple of attribute names
+	resolver := func(name string) (Style, bool) {
+		switch name {
+		case "bold":
+			return "\x1b[1m", true
+		case "red":
+			return "\x1b[31m", true
+		}
+		return "", false
+	}
+	colorCtx := &RenderContext{ColorLogging: true, LogLevelStyle: "\x1b[32m", TagStyles: []Style{"\x1b[34m"}, StyleResolver: resolver, Markup: resolver}
+
+	tests := []textTemplateTest{
+		{
+			`{{.Timestamp}} {{pad 5 .RawLevel}}|{{join "," .RawTags}}| {{.Message}}{{range .Fields}} {{.}}{{end}}`,
+			&RenderContext{},
+			`2006-01-02T15:04:05Z INFO |tag1,tag2| Test <bold>string</bold> 123 user=alice err=failed`,
+		},
+		{
+			`{{pad -6 .Level}} {{upper .Level}} {{lower .RawLevel}} {{join " " .Tags}} {{color "bold" .Message}} {{color "unknown" "plain"}}`,
+			colorCtx,
+			"  \x1b[32mINFO\x1b[0m \x1b[32mINFO\x1b[0m info \x1b[34mtag1\x1b[0m tag2 \x1b[1mTest \x1b[1mstring\x1b[0m 123\x1b[0m plain",
+		},
+		{
+			`{{color "red" .RawLevel}} {{.Message}}`,
+			&RenderContext{Markup: resolver},
+			`INFO Test string 123`,
+		},
+		{
+			`{{json .Fields}} {{json (.Field "err")}} {{json (.Field "missing")}} {{json .Time}}`,
+			&RenderContext{},
+			`[{"key":"user","value":"alice"},{"key":"err","value":{}}] "failed" null "2006-01-02T15:04:05Z"`,
+		},
+		{
+			`{{.Caller.Function}} {{.Caller.Line}} {{.Time.Year}} {{len .Variables}}`,
+			&RenderContext{},
+			`main.run 12 2006 4`,
+		},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Create the formatter
+		formatter, err := Template(test.template)
+		if err != nil {
+			t.Errorf("[%d] %v", i, err)
+			continue
+		}
+
+		// Call the function twice to check that the template can be reused
+		for j := 0; j < 2; j++ {
+			b, err := formatter.Format([]byte("prefix "), record, test.ctx)
+			if err != nil {
+				t.Error(err)
+			}
+
+			// Check if the output is correct
+			if output := string(b); output != "prefix "+test.expected {
+				t.Errorf("[%d] Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", i, "prefix "+test.expected, output)
+			}
+		}
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+
+	// Parse errors should be returned when the formatter is created
+	for i, tmpl := range []string{`{{.Message`, `{{unknown .Message}}`} {
+		if _, err := Template(tmpl); err == nil {
+			t.Errorf("[%d] Incorrect output. Expected an error, received nil", i)
+		}
+	}
+
+	// Execution errors should be returned without writing a partial log
+	formatter := MustTemplate(`{{.Message}} {{.Missing}}`)
+	b, err := formatter.Format([]byte("prefix "), &Record{LogLevel: "INFO"}, &RenderContext{})
+	if err == nil || string(b) != "prefix " {
+		t.Errorf("Incorrect output. Expected an error and 'prefix ', received '%v' and '%s'", err, b)
+	}
+}
@@ -11,26 +11,113 @@ func TestText(t *testing.T) {
 	const expected = `2006-01-02T15:04:05Z [INFO] [#tag1 #tag2] Test string 123 4.5 true`
 
 	// Test log
-	var (
-		logLevel  = "INFO"
-		variables = []interface{}{"Test string", 123, 4.5, true}
-		timestamp = time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC).Format(time.RFC3339)
-		tags      = []string{"tag1", "tag2"}
-	)
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Variables: []interface{}{"Test string", 123, 4.5, true},
+		Tags:      []string{"tag1", "tag2"},
+	}
 
 	// Call the function
-	output, err := Text(
-		timestamp,
-		logLevel,
-		variables,
-		tags,
-	)
+	b, err := TextRecord.Format(nil, record, &RenderContext{})
 	if err != nil {
 		t.Error(err)
 	}
 
 	// Check if the output is correct
-	if output != expected {
+	if output := string(b); output != expected {
 		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
 	}
 }
+
+func TestTextColor(t *testing.T) {
+
+	// Expected output
+	const expected = "2006-01-02T15:04:05Z [\x1b[32mINFO\x1b[0m] [\x1b[31m#tag1\x1b[0m #tag2] Test string"
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Variables: []interface{}{"Test string"},
+		Tags:      []string{"tag1", "tag2"},
+	}
+	ctx := &RenderContext{
+		ColorLogging:  true,
+		LogLevelStyle: "\x1b[32m",
+		TagStyles:     []Style{"\x1b[31m", ""},
+	}
+
+	// Call the function
+	b, err := TextRecord.Format(nil, record, ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if output := string(b); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestTextTemplate(t *testing.T) {
+
+	// Expected output
+	const expected = `2006-01-02T15:04:05Z [INFO] [#tag1] user alice logged in from 10.0.0.1 extra`
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     4,
+		LogLevel:  "INFO",
+		Template:  "user {user} logged in from {ip}",
+		Variables: []interface{}{Field{"user", "alice"}, Field{"ip", "10.0.0.1"}, "extra"},
+		Tags:      []string{"tag1"},
+	}
+
+	// Call the function
+	b, err := TextRecord.Format(nil, record, &RenderContext{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if output := string(b); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestTextLineStyle(t *testing.T) {
+
+	// Expected output
+	const expected = "\x1b[7m2006-01-02T15:04:05Z [\x1b[31mERROR\x1b[0m\x1b[7m] [\x1b[2m#tag1\x1b[0m\x1b[7m] \x1b[1mTest string\x1b[0m\x1b[7m\x1b[0m"
+
+	// Test log
+	record := &Record{
+		Timestamp: Timestamp{Time: time.Date(2006, 01, 02, 15, 04, 05, 0, time.UTC), Format: time.RFC3339},
+		Level:     2,
+		LogLevel:  "ERROR",
+		Variables: []interface{}{"Test string"},
+		Tags:      []string{"tag1"},
+	}
+	ctx := &RenderContext{
+		ColorLogging:  true,
+		LogLevelStyle: "\x1b[31m",
+		TagStyles:     []Style{"\x1b[2m"},
+		MessageStyle:  "\x1b[1m",
+		LineStyle:     "\x1b[7m",
+	}
+
+	// Call the function, the line style should be applied again after each reset
+	b, err := TextRecord.Format([]byte("prefix "), record, ctx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if output := string(b); output != "prefix "+expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", "prefix "+expected, output)
+	}
+}
@@ -0,0 +1,91 @@
+package formatters
+
+import (
+	"strconv"
+	"time"
+)
+
+// TimestampEncoding dictates how a timestamp should be rendered.
+type TimestampEncoding int
+
+// Available timestamp encodings:
+const (
+	// FormattedTimestamp renders the time using the timestamp format
+	FormattedTimestamp TimestampEncoding = iota
+	// UnixSeconds renders the number of seconds since the Unix epoch
+	UnixSeconds
+	// UnixMillis renders the number of milliseconds since the Unix epoch
+	UnixMillis
+	// UnixNanos renders the number of nanoseconds since the Unix epoch
+	UnixNanos
+	// ElapsedTimestamp renders the time elapsed since the logger was created
+	ElapsedTimestamp
+	// DeltaTimestamp renders the time elapsed since the logger's previous log
+	DeltaTimestamp
+)
+
+// A Timestamp holds the raw time of a log along with the settings that describe how it should be rendered.
+// Each formatter can choose how to encode the timestamp. e.g. JSON will output Unix timestamps as numbers.
+type Timestamp struct {
+	Time     time.Time
+	Format   string
+	Encoding TimestampEncoding
+	Elapsed  time.Duration
+	Delta    time.Duration
+	Rendered string // If set, the timestamp was rendered before it reached the formatter and is written as it is
+}
+
+// String will render the timestamp according to its encoding.
+func (timestamp Timestamp) String() string {
+	return string(timestamp.Append(nil))
+}
+
+// Append will append the rendered timestamp to dst according to its encoding.
+func (timestamp Timestamp) Append(dst []byte) []byte {
+
+	if timestamp.Rendered != "" {
+		return append(dst, timestamp.Rendered...)
+	}
+
+	switch timestamp.Encoding {
+	case UnixSeconds:
+		return strconv.AppendInt(dst, timestamp.Time.Unix(), 10)
+	case UnixMillis:
+		return strconv.AppendInt(dst, timestamp.Time.UnixNano()/int64(time.Millisecond), 10)
+	case UnixNanos:
+		return strconv.AppendInt(dst, timestamp.Time.UnixNano(), 10)
+	case ElapsedTimestamp:
+		return append(append(dst, '+'), timestamp.Elapsed.String()...)
+	case DeltaTimestamp:
+		return append(append(dst, '+'), timestamp.Delta.String()...)
+	default:
+		return timestamp.Time.AppendFormat(dst, timestamp.Format)
+	}
+}
+
+// AppendJSON will append the timestamp to dst as JSON.
+// Unix timestamps are encoded as JSON numbers and any other encoding as a JSON string.
+func (timestamp Timestamp) AppendJSON(dst []byte) []byte {
+	switch timestamp.Encoding {
+	case UnixSeconds, UnixMillis, UnixNanos:
+		return timestamp.Append(dst)
+	default:
+		dst = append(dst, '"')
+		n := len(dst)
+		dst = timestamp.Append(dst)
+
+		// Only escape the timestamp if it contains characters that need escaping
+		for _, b := range dst[n:] {
+			if b < 0x20 || b == '"' || b == '\\' || b == '<' || b == '>' || b == '&' || b >= 0x80 {
+				return appendJSONString(dst[:n-1], string(dst[n:]))
+			}
+		}
+
+		return append(dst, '"')
+	}
+}
+
+// MarshalJSON will encode Unix timestamps as JSON numbers and any other encoding as a JSON string.
+func (timestamp Timestamp) MarshalJSON() ([]byte, error) {
+	return timestamp.AppendJSON(nil), nil
+}
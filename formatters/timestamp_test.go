@@ -0,0 +1,45 @@
+package formatters
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type timestampTest struct {
+	timestamp    Timestamp
+	expected     string
+	expectedJSON string
+}
+
+func TestTimestamp(t *testing.T) {
+
+	now := time.Date(2006, 01, 02, 15, 04, 05, 123456789, time.UTC)
+
+	tests := []timestampTest{
+		{Timestamp{Time: now, Format: time.RFC3339}, "2006-01-02T15:04:05Z", `"2006-01-02T15:04:05Z"`},
+		{Timestamp{Time: now, Encoding: UnixSeconds}, "1136214245", "1136214245"},
+		{Timestamp{Time: now, Encoding: UnixMillis}, "1136214245123", "1136214245123"},
+		{Timestamp{Time: now, Encoding: UnixNanos}, "1136214245123456789", "1136214245123456789"},
+		{Timestamp{Time: now, Encoding: ElapsedTimestamp, Elapsed: 1500 * time.Millisecond}, "+1.5s", `"+1.5s"`},
+		{Timestamp{Time: now, Encoding: DeltaTimestamp, Delta: 250 * time.Microsecond}, "+250µs", `"+250µs"`},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Check if the string output is correct
+		if output := test.timestamp.String(); output != test.expected {
+			t.Errorf("[%d] Incorrect output. Expected '%s', received '%s'", i, test.expected, output)
+		}
+
+		// Check if the JSON output is correct
+		b, err := json.Marshal(test.timestamp)
+		if err != nil {
+			t.Error(err)
+		}
+		if string(b) != test.expectedJSON {
+			t.Errorf("[%d] Incorrect JSON output. Expected '%s', received '%s'", i, test.expectedJSON, string(b))
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package plog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// GELFCompression dictates how GELF messages sent over UDP are compressed.
+type GELFCompression int
+
+// Available GELF compression methods:
+const (
+	// GELFGzip compresses messages with gzip
+	GELFGzip GELFCompression = iota
+	// GELFZlib compresses messages with zlib
+	GELFZlib
+	// GELFNoCompression sends messages uncompressed
+	GELFNoCompression
+)
+
+// DefaultGELFChunkSize is the largest UDP packet that is sent before a message is split into chunks.
+// It fits inside the MTU of most networks.
+const DefaultGELFChunkSize = 1420
+
+// DefaultGELFTimeout is how long the output waits to connect to the server or to send a message.
+const DefaultGELFTimeout = 5 * time.Second
+
+// GELF limits
+const (
+	gelfChunkHeaderSize = 12
+	maxGELFChunks       = 128
+)
+
+//
+// Structures
+//
+
+// GELFOutput sends GELF messages to a Graylog server (or any other GELF input).
+// Messages sent over UDP are compressed and split into chunks if they are too large.
+// Messages sent over TCP are uncompressed and separated by null bytes.
+// It should be used with the `formatters.GELF` formatter.
+type GELFOutput struct {
+	network     string
+	address     string
+	compression GELFCompression
+	chunkSize   int
+	timeout     time.Duration
+	conn        net.Conn
+	buffer      bytes.Buffer
+	mutex       sync.Mutex
+}
+
+// A GELFOption is a function that sets an option on a given GELF output.
+type GELFOption func(output *GELFOutput)
+
+//
+// Constructors
+//
+
+// NewGELFOutput will create a GELF output and connect it to the given address.
+// The network must be "udp", "udp4", "udp6", "tcp", "tcp4" or "tcp6".
+// Any number of additional functional options can be passed to this method and they will be applied on creation.
+// You can read more information on functional options on the PLog wiki: https://github.com/pd93/plog/wiki/Functional-Options.
+func NewGELFOutput(network, address string, opts ...GELFOption) (output *GELFOutput, err error) {
+
+	// Check that the network is supported
+	switch network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("Unsupported GELF network: '%s'", network)
+	}
+
+	// Create a default GELF output
+	output = &GELFOutput{
+		network:     network,
+		address:     address,
+		compression: GELFGzip,
+		chunkSize:   DefaultGELFChunkSize,
+		timeout:     DefaultGELFTimeout,
+	}
+
+	// Apply the custom options
+	output.Options(opts...)
+
+	// Connect to the server
+	if output.conn, err = net.DialTimeout(network, address, output.timeout); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+//
+// Functional Options
+//
+
+// WithGELFCompression will return a function that sets how messages sent over UDP are compressed.
+// Messages sent over TCP are never compressed.
+func WithGELFCompression(compression GELFCompression) GELFOption {
+	return func(output *GELFOutput) {
+		output.compression = compression
+	}
+}
+
+// WithGELFChunkSize will return a function that sets the largest UDP packet that can be sent.
+// Larger messages are split into a maximum of 128 chunks.
+func WithGELFChunkSize(chunkSize int) GELFOption {
+	return func(output *GELFOutput) {
+		output.chunkSize = chunkSize
+	}
+}
+
+// WithGELFTimeout will return a function that sets how long the output waits to connect to the server or to send a message.
+// Messages are written while the logger is locked, so a server that stops responding will only block logging for this long.
+// If the timeout is 0, the output waits forever.
+func WithGELFTimeout(timeout time.Duration) GELFOption {
+	return func(output *GELFOutput) {
+		output.timeout = timeout
+	}
+}
+
+//
+// Options Setter
+//
+
+// Options will apply the given options to the GELF output.
+// Any number of functional options can be passed to this method.
+// You can read more information on functional options on the PLog wiki: https://github.com/pd93/plog/wiki/Functional-Options.
+func (output *GELFOutput) Options(opts ...GELFOption) {
+
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+
+	// Loop through each option and apply it
+	for _, opt := range opts {
+		opt(output)
+	}
+
+	// Chunks must have room for their header
+	if output.chunkSize <= gelfChunkHeaderSize {
+		output.chunkSize = DefaultGELFChunkSize
+	}
+}
+
+//
+// Getters
+//
+
+// Network will return the network that the GELF output sends messages over.
+func (output *GELFOutput) Network() string {
+	return output.network
+}
+
+// Address will return the address that the GELF output sends messages to.
+func (output *GELFOutput) Address() string {
+	return output.address
+}
+
+// Compression will return how the GELF output compresses messages sent over UDP.
+func (output *GELFOutput) Compression() GELFCompression {
+	return output.compression
+}
+
+// ChunkSize will return the largest UDP packet that the GELF output sends.
+func (output *GELFOutput) ChunkSize() int {
+	return output.chunkSize
+}
+
+// Timeout will return how long the GELF output waits to connect to the server or to send a message.
+func (output *GELFOutput) Timeout() time.Duration {
+	return output.timeout
+}
+
+//
+// Instance methods
+//
+
+// Write will send a single GELF message. Any trailing newline is removed.
+// If the message cannot be sent, the output reconnects and tries again once before returning an error.
+func (output *GELFOutput) Write(p []byte) (n int, err error) {
+
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+
+	// Remove the newline added by the logger
+	message := bytes.TrimRight(p, "\r\n")
+
+	// Send the message, reconnecting if the connection has failed
+	if output.conn != nil {
+		if err = output.send(message); err == nil {
+			return len(p), nil
+		}
+		output.conn.Close()
+		output.conn = nil
+	}
+	if output.conn, err = net.DialTimeout(output.network, output.address, output.timeout); err != nil {
+		return 0, err
+	}
+	if err = output.send(message); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close will close the connection to the server.
+func (output *GELFOutput) Close() error {
+
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+
+	if output.conn == nil {
+		return nil
+	}
+
+	err := output.conn.Close()
+	output.conn = nil
+
+	return err
+}
+
+// send will send a message using the framing of the output's network.
+func (output *GELFOutput) send(message []byte) error {
+
+	// Don't block the logger if the server stops reading
+	if output.timeout > 0 {
+		if err := output.conn.SetWriteDeadline(time.Now().Add(output.timeout)); err != nil {
+			return err
+		}
+	}
+
+	// TCP messages are separated by null bytes
+	if output.network[:3] == "tcp" {
+		output.buffer.Reset()
+		output.buffer.Write(message)
+		output.buffer.WriteByte(0)
+		_, err := output.conn.Write(output.buffer.Bytes())
+		return err
+	}
+
+	// Compress UDP messages
+	if err := output.compress(message); err != nil {
+		return err
+	}
+	message = output.buffer.Bytes()
+
+	// Send small messages in a single packet
+	if len(message) <= output.chunkSize {
+		_, err := output.conn.Write(message)
+		return err
+	}
+
+	return output.sendChunks(message)
+}
+
+// compress will compress the message into the output's buffer.
+func (output *GELFOutput) compress(message []byte) (err error) {
+
+	output.buffer.Reset()
+
+	switch output.compression {
+	case GELFGzip:
+		writer := gzip.NewWriter(&output.buffer)
+		if _, err = writer.Write(message); err != nil {
+			return
+		}
+		return writer.Close()
+	case GELFZlib:
+		writer := zlib.NewWriter(&output.buffer)
+		if _, err = writer.Write(message); err != nil {
+			return
+		}
+		return writer.Close()
+	default:
+		_, err = output.buffer.Write(message)
+		return
+	}
+}
+
+// sendChunks will split a message into GELF chunks and send each one in its own packet.
+// Each chunk starts with the magic bytes, a message ID shared by all chunks, the sequence number and the sequence count.
+func (output *GELFOutput) sendChunks(message []byte) error {
+
+	// Check that the message isn't too large
+	size := output.chunkSize - gelfChunkHeaderSize
+	count := (len(message) + size - 1) / size
+	if count > maxGELFChunks {
+		return errors.New("GELF message is too large to be sent in 128 chunks")
+	}
+
+	// Create the header
+	header := [gelfChunkHeaderSize]byte{0x1e, 0x0f}
+	if _, err := rand.Read(header[2:10]); err != nil {
+		return err
+	}
+	header[11] = byte(count)
+
+	// Send each chunk
+	chunk := make([]byte, 0, output.chunkSize)
+	for i := 0; i < count; i++ {
+		header[10] = byte(i)
+		end := (i + 1) * size
+		if end > len(message) {
+			end = len(message)
+		}
+		chunk = append(append(chunk[:0], header[:]...), message[i*size:end]...)
+		if _, err := output.conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
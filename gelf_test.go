@@ -0,0 +1,188 @@
+package plog
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pd93/plog/formatters"
+	"github.com/pd93/plog/mocks"
+)
+
+func TestGELFOutputUDP(t *testing.T) {
+
+	// Start a local UDP listener
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Create a GELF logger
+	output, err := NewGELFOutput("udp", conn.LocalAddr().String(), WithGELFChunkSize(64))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+	logger := NewGELFLogger(output, WithClock(mocks.Now), WithRecordFormatter(formatters.NewGELF("host1")))
+
+	// Write a log that is too large to fit in a single packet
+	message := strings.Repeat("Test string ", 50)
+	logger.Info(message)
+
+	// Read and reassemble the chunks
+	var chunks [][]byte
+	buffer := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for count := 1; len(chunks) < count; {
+		n, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n > 64 || buffer[0] != 0x1e || buffer[1] != 0x0f {
+			t.Fatalf("Incorrect output. Expected a chunk, received '%x'", buffer[:n])
+		}
+		if chunks == nil {
+			count = int(buffer[11])
+			chunks = make([][]byte, 0, count)
+		}
+		if int(buffer[10]) != len(chunks) {
+			t.Fatalf("Incorrect output. Expected chunk %d, received chunk %d", len(chunks), buffer[10])
+		}
+		chunks = append(chunks, append([]byte(nil), buffer[12:n]...))
+	}
+
+	// Decompress the message
+	reader, err := gzip.NewReader(bytes.NewReader(bytes.Join(chunks, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Check if the output is correct
+	expected := `{"version":"1.1","host":"host1","short_message":"` + message + `","timestamp":1136214245,"level":6,"_log_level":"INFO"}`
+	if output := string(b); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestGELFOutputTCP(t *testing.T) {
+
+	// Start a local TCP listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// Read messages from each connection
+	messages := make(chan string)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				for {
+					message, err := reader.ReadString(0)
+					if err != nil {
+						return
+					}
+					messages <- message
+				}
+			}()
+		}
+	}()
+
+	// Create a GELF logger
+	output, err := NewGELFOutput("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+	logger := NewGELFLogger(output, WithClock(mocks.Now), WithRecordFormatter(formatters.NewGELF("host1")))
+
+	// Write a log, break the connection and write another
+	logger.TWarn(Tags{"tag1"}, "Test string", 123)
+	expected := `{"version":"1.1","host":"host1","short_message":"Test string 123","timestamp":1136214245,"level":4,"_log_level":"WARN","_tags":"tag1"}` + "\x00"
+	if output := receiveMessage(t, messages); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expected, output)
+	}
+	output.conn.Close()
+	logger.Debug("Test string")
+	expected = `{"version":"1.1","host":"host1","short_message":"Test string","timestamp":1136214245,"level":7,"_log_level":"DEBUG"}` + "\x00"
+	if output := receiveMessage(t, messages); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expected, output)
+	}
+}
+
+func TestGELFOutputTimeout(t *testing.T) {
+
+	// Start a local TCP listener that never reads its connections
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	// Create a GELF output with a short timeout
+	output, err := NewGELFOutput("tcp", listener.Addr().String(), WithGELFTimeout(50*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	// Writing more than the socket buffers can hold should fail instead of blocking
+	errs := make(chan error)
+	go func() {
+		_, err := output.Write(bytes.Repeat([]byte("a"), 64<<20))
+		errs <- err
+	}()
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Error("Incorrect output. Expected an error, received nil")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the write to fail")
+	}
+}
+
+func TestNewGELFOutput(t *testing.T) {
+
+	// Unsupported networks should return an error
+	if _, err := NewGELFOutput("unix", "/dev/null"); err == nil {
+		t.Error("Incorrect output. Expected an error, received nil")
+	}
+}
+
+// receiveMessage will wait for a message from a test listener.
+func receiveMessage(t *testing.T, messages chan string) string {
+	select {
+	case message := <-messages:
+		return message
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a message")
+		return ""
+	}
+}
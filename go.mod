@@ -1,3 +1,3 @@
 module github.com/pd93/plog
 
-go 1.12
+go 1.17
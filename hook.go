@@ -0,0 +1,7 @@
+package plog
+
+// A Hook is a function that is called with every log that a logger is about to write.
+// Hooks are called after the log level check, but before the log is formatted.
+// The log's variables and tags have already been redacted and the message of a formatting function (e.g. `Infof`) has been rendered.
+// Logs are reused once they have been written, so hooks must copy any data they want to keep.
+type Hook func(log *Log)
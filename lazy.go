@@ -0,0 +1,37 @@
+package plog
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Lazy is a function that returns a value to be logged.
+// The function is only called if at least one logger is going to write the log,
+// so expensive values (e.g. debug dumps) cost nothing when their log level is disabled.
+// Lazy values can be passed as variables, field values or arguments to formatted logging functions (e.g. `Debugf()`).
+type Lazy func() interface{}
+
+// Format will evaluate the lazy value and format the result using the same verb and flags.
+// This allows lazy values to be used with the formatted logging functions.
+func (lazy Lazy) Format(f fmt.State, verb rune) {
+
+	format := []byte{'%'}
+
+	// Add any flags
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			format = append(format, byte(flag))
+		}
+	}
+
+	// Add the width and precision
+	if width, ok := f.Width(); ok {
+		format = strconv.AppendInt(format, int64(width), 10)
+	}
+	if precision, ok := f.Precision(); ok {
+		format = append(format, '.')
+		format = strconv.AppendInt(format, int64(precision), 10)
+	}
+
+	fmt.Fprintf(f, string(append(format, string(verb)...)), lazy())
+}
@@ -0,0 +1,112 @@
+package plog
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/pd93/plog/mocks"
+)
+
+func TestLazy(t *testing.T) {
+
+	// Expected output
+	const expected = "2006-01-02T15:04:05Z [INFO] Test string 123 key=abc\n2006-01-02T15:04:05Z [INFO] 0123 value\n"
+
+	// Create a logger
+	var buffer bytes.Buffer
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithColorLogging(false),
+		WithClock(mocks.Now),
+	)
+
+	// Count the number of times the values are evaluated
+	var calls int
+	lazy := func(value interface{}) Lazy {
+		return func() interface{} {
+			calls++
+			return value
+		}
+	}
+
+	// Disabled logs should not evaluate their lazy values
+	logger.Debug("Test string", lazy(123), NewField("key", lazy("abc")))
+	logger.Debugf("%04d", lazy(123))
+	if calls != 0 {
+		t.Errorf("Expected lazy values not to be evaluated, evaluated %d times", calls)
+	}
+
+	// Enabled logs should evaluate each lazy value once
+	logger.Info("Test string", lazy(123), NewField("key", lazy("abc")))
+	logger.Infof("%04d %s\n", lazy(123), lazy("value"))
+	if calls != 4 {
+		t.Errorf("Expected lazy values to be evaluated 4 times, evaluated %d times", calls)
+	}
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestLazyGlobal(t *testing.T) {
+
+	// Create two global loggers
+	var buffer1, buffer2 bytes.Buffer
+	AddLogger("lazy1", NewLogger(WithOutput(&buffer1), WithColorLogging(false), WithFormatter(plainFormatter)))
+	AddLogger("lazy2", NewLogger(WithOutput(&buffer2), WithColorLogging(false), WithFormatter(plainFormatter), WithLogLevel(TraceLevel)))
+	defer DeleteLogger("lazy1")
+	defer DeleteLogger("lazy2")
+
+	// Count the number of times the value is evaluated
+	var calls int
+	lazy := Lazy(func() interface{} {
+		calls++
+		return calls
+	})
+
+	// The value should be evaluated once, even though it is written to two loggers
+	Info(lazy)
+	if calls != 1 || buffer1.String() != "1\n" || buffer2.String() != "1\n" {
+		t.Errorf("Incorrect output. Evaluated %d times: '%s', '%s'", calls, buffer1.String(), buffer2.String())
+	}
+
+	// The value should only be evaluated if at least one logger writes it
+	Trace(lazy)
+	TTrace(Tags{"tag1"}, lazy)
+	Tracef("%v", lazy)
+	if calls != 4 {
+		t.Errorf("Expected the value to be evaluated 4 times, evaluated %d times", calls)
+	}
+
+	// No logger writes at this level
+	DeleteLogger("lazy2")
+	AddLogger("lazy2", NewLogger(WithGlobalLogging(false)))
+	Trace(lazy)
+	if calls != 4 {
+		t.Errorf("Expected the value to be evaluated 4 times, evaluated %d times", calls)
+	}
+}
+
+// plainFormatter will print the variables only.
+func plainFormatter(timestamp, logLevel string, variables []interface{}, tags []string) (string, error) {
+	return fmt.Sprint(variables...), nil
+}
+
+func TestEnabled(t *testing.T) {
+
+	logger := NewLogger(WithLogLevel(WarnLevel))
+
+	// Check the logger
+	if !logger.Enabled(ErrorLevel) || !logger.Enabled(WarnLevel) || logger.Enabled(InfoLevel) {
+		t.Error("Logger is enabled at the wrong log levels")
+	}
+
+	// Check the global loggers
+	AddLogger("enabled", logger)
+	defer DeleteLogger("enabled")
+	if !Enabled(WarnLevel) || Enabled(InfoLevel) {
+		t.Error("Global loggers are enabled at the wrong log levels")
+	}
+}
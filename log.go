@@ -1,60 +1,119 @@
 package plog
 
 import (
-	"fmt"
+	"sync"
 	"time"
+
+	"github.com/pd93/plog/formatters"
 )
 
 // A Log holds a single message along with its log level and timestamp.
 type Log struct {
 	logLevel  LogLevel
 	variables []interface{}
+	format    string // The format of the message, if the log was written with a formatting function (e.g. Infof)
+	formatted bool   // Whether or not the variables are the arguments of the format
+	template  string
 	timestamp time.Time
 	tags      Tags
-	newLine   bool
+}
+
+// Logs are reused to avoid allocating a new log for every message.
+var logPool = sync.Pool{
+	New: func() interface{} {
+		return new(Log)
+	},
 }
 
 // newLog creates a new instance of log and populates it with a log level and a message.
-// A timestamp is also generated and stored.
+// The timestamp is stored by each logger when the log is written, using the logger's clock.
+// The variables are copied into the log so that the caller's slice does not escape to the heap.
 func newLog(logLevel LogLevel, variables ...interface{}) *Log {
-	return &Log{
-		logLevel:  logLevel,
-		variables: variables,
-		timestamp: time.Now(),
-		newLine:   true,
-	}
+	log := logPool.Get().(*Log)
+	log.logLevel = logLevel
+	log.variables = append(log.variables[:0], variables...)
+	log.format = ""
+	log.formatted = false
+	log.template = ""
+	log.tags = log.tags[:0]
+	return log
 }
 
 // newLogf creates a new instance of log and populates it with a log level and a formatted message.
 // You can send any number of variables to this function and they will be printed according to the format specified.
-// A timestamp is also generated and stored.
+// The message is rendered by each logger when the log is written so that the variables can be redacted first.
 func newLogf(level LogLevel, format string, variables ...interface{}) *Log {
-	log := newLog(level, fmt.Sprintf(format, variables...))
-	log.newLine = false
+	log := newLog(level, variables...)
+	log.format = format
+	log.formatted = true
 	return log
 }
 
 // newTLog creates a new instance of log and populates it with a log level, a message and a series of meta-tags.
-// A timestamp is also generated and stored.
+// The timestamp is stored by each logger when the log is written, using the logger's clock.
 func newTLog(logLevel LogLevel, tags Tags, variables ...interface{}) *Log {
-	return &Log{
-		logLevel:  logLevel,
-		variables: variables,
-		timestamp: time.Now(),
-		tags:      tags,
-		newLine:   true,
-	}
+	log := newLog(logLevel, variables...)
+	log.tags = append(log.tags, tags...)
+	return log
 }
 
 // newTLogf creates a new instance of log and populates it with a log level, a formatted message and a series of meta-tags.
 // You can send any number of variables to this function and they will be printed according to the format specified.
-// A timestamp is also generated and stored.
 func newTLogf(level LogLevel, tags Tags, format string, variables ...interface{}) *Log {
-	log := newTLog(level, tags, fmt.Sprintf(format, variables...))
-	log.newLine = false
+	log := newLogf(level, format, variables...)
+	log.tags = append(log.tags, tags...)
 	return log
 }
 
+// newLogt creates a new instance of log and populates it with a log level and a message template.
+// Each variable is stored as a field named after its placeholder so that formatters can keep the values structured.
+// Any variables without a placeholder are added to the log as they are.
+func newLogt(level LogLevel, template string, variables ...interface{}) *Log {
+	log := newLog(level, variables...)
+	log.template = template
+
+	// Loop through the placeholders and name the values
+	for i, name := range formatters.Placeholders(template) {
+		if i >= len(log.variables) {
+			break
+		}
+		log.variables[i] = NewField(name, log.variables[i])
+	}
+
+	return log
+}
+
+// newTLogt creates a new instance of log and populates it with a log level, a message template and a series of meta-tags.
+func newTLogt(level LogLevel, tags Tags, template string, variables ...interface{}) *Log {
+	log := newLogt(level, template, variables...)
+	log.tags = append(log.tags, tags...)
+	return log
+}
+
+// resolve will replace any lazy variables and lazy field values with the values they return.
+func (log *Log) resolve() {
+	for i, variable := range log.variables {
+		switch v := variable.(type) {
+		case Lazy:
+			log.variables[i] = v()
+		case Field:
+			if lazy, ok := v.Value.(Lazy); ok {
+				v.Value = lazy()
+				log.variables[i] = v
+			}
+		}
+	}
+}
+
+// release will return the log to the pool once it has been written.
+// The variables are cleared so that the pool does not keep them alive.
+func (log *Log) release() {
+	for i := range log.variables {
+		log.variables[i] = nil
+	}
+	logPool.Put(log)
+}
+
 //
 // Getters
 //
@@ -69,6 +128,12 @@ func (log *Log) Variables() []interface{} {
 	return log.variables
 }
 
+// Template will return the message template of the log.
+// If the log was not written with a template, an empty string is returned.
+func (log *Log) Template() string {
+	return log.template
+}
+
 // Timestamp will return the log creation timestamp.
 func (log *Log) Timestamp() time.Time {
 	return log.timestamp
@@ -78,3 +143,16 @@ func (log *Log) Timestamp() time.Time {
 func (log *Log) Tags() Tags {
 	return log.tags
 }
+
+// Fields will return any variables that are fields (key/value pairs).
+func (log *Log) Fields() (fields []Field) {
+
+	// Loop through the variables and pick out the fields
+	for _, variable := range log.variables {
+		if field, ok := variable.(Field); ok {
+			fields = append(fields, field)
+		}
+	}
+
+	return
+}
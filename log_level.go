@@ -1,5 +1,12 @@
 package plog
 
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pd93/plog/formatters"
+)
+
 // LogLevel dictates when a logged message should be displayed or recorded.
 type LogLevel int
 
@@ -46,8 +53,39 @@ func (logLevel LogLevel) String(colorLogging bool, logLevelColorMap LogLevelColo
 
 	// Check if color logging is enabled and whether there is a color for this log level in the map
 	if attributes, ok := logLevelColorMap[logLevel]; colorLogging && ok {
-		return Color(str, attributes...)
+		return string(Color(str, attributes...))
 	}
 
 	return
 }
+
+// MarshalText will encode the log level as its uncolored name.
+// This allows log levels to be used as keys when encoding maps as JSON.
+func (logLevel LogLevel) MarshalText() ([]byte, error) {
+	return []byte(logLevel.String(false, nil)), nil
+}
+
+// UnmarshalText will decode a log level from its name. The name is not case sensitive.
+func (logLevel *LogLevel) UnmarshalText(text []byte) error {
+
+	// Loop through the log levels and compare their names
+	for l := None; l <= TraceLevel; l++ {
+		if strings.EqualFold(l.String(false, nil), string(text)) {
+			*logLevel = l
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Invalid log level: '%s'", text)
+}
+
+// style will return the style for the log level if color logging is enabled.
+func (logLevel LogLevel) style(colorLogging bool, logLevelColorMap LogLevelColorMap) formatters.Style {
+
+	// Check if color logging is enabled and whether there is a color for this log level in the map
+	if attributes, ok := logLevelColorMap[logLevel]; colorLogging && ok {
+		return newStyle(attributes)
+	}
+
+	return ""
+}
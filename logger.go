@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pd93/plog/formatters"
@@ -15,14 +17,38 @@ import (
 
 // A Logger is a channel for writing logs.
 type Logger struct {
-	output           io.Writer
-	logLevel         LogLevel
-	formatter        Formatter
-	timestampFormat  string
-	colorLogging     bool
-	logLevelColorMap LogLevelColorMap
-	tagColorMap      TagColorMap
-	globalLogging    bool
+	output             io.Writer
+	logLevel           LogLevel
+	formatter          Formatter       // The formatter set using WithFormatter, if any
+	recordFormatter    RecordFormatter // The formatter that writes each log
+	timestampFormat    string
+	timestampLocation  *time.Location
+	timestampPrecision time.Duration
+	timestampEncoding  formatters.TimestampEncoding
+	colorLogging       bool
+	autoColorLogging   bool
+	logLevelColorMap   LogLevelColorMap
+	tagColorMap        TagColorMap
+	theme              Theme
+	levelStyling       LevelStyling
+	autoTagColors      bool
+	background         Background
+	tagPalette         tagPalette     // The colors given to unmapped tags, built when first needed
+	colorMapStyles     colorMapStyles // The color maps rendered for the render context, built when first needed
+	globalLogging      bool
+	hooks              []Hook
+	timerLogLevel      LogLevel
+	timerThreshold     time.Duration
+	clock              Clock
+	redactor           *Redactor
+	sanitize           bool
+	markup             bool
+	multiline          bool
+	caller             bool
+	start              time.Time  // The time the logger was created
+	prev               time.Time  // The time of the previous log
+	mutex              sync.Mutex // Serializes writes to the output
+	counters           *loggerCounters
 }
 
 // A LoggerOption is a function that sets an option on a given logger.
@@ -38,19 +64,28 @@ type LoggerOption func(logger *Logger)
 func NewLogger(opts ...LoggerOption) (logger *Logger) {
 
 	// Create a default logger
+	theme := DefaultTheme()
 	logger = &Logger{
 		output:           os.Stdout,
 		logLevel:         InfoLevel,
-		formatter:        formatters.Text,
+		recordFormatter:  formatters.TextRecord,
 		timestampFormat:  time.RFC3339,
-		colorLogging:     true,
-		logLevelColorMap: NewLogLevelColorMap(),
-		tagColorMap:      NewTagColorMap(),
+		autoColorLogging: true,
+		logLevelColorMap: theme.LogLevels,
+		tagColorMap:      theme.Tags,
+		theme:            theme,
 		globalLogging:    true,
+		timerLogLevel:    InfoLevel,
+		clock:            time.Now,
+		counters:         &loggerCounters{},
 	}
 
 	logger.Options(opts...)
 
+	// Start the clock for elapsed timestamps
+	logger.start = logger.clock()
+	logger.prev = logger.start
+
 	return
 }
 
@@ -84,7 +119,7 @@ func NewJSONFileLogger(file *File, opts ...LoggerOption) *Logger {
 	opts = append([]LoggerOption{
 		WithOutput(file),
 		WithLogLevel(TraceLevel),
-		WithFormatter(formatters.JSON),
+		WithRecordFormatter(formatters.JSONRecord),
 		WithColorLogging(false),
 	}, opts...)
 
@@ -103,7 +138,43 @@ func NewCSVFileLogger(file *File, opts ...LoggerOption) *Logger {
 	opts = append([]LoggerOption{
 		WithOutput(file),
 		WithLogLevel(TraceLevel),
-		WithFormatter(formatters.CSV),
+		WithRecordFormatter(formatters.CSVRecord),
+		WithColorLogging(false),
+	}, opts...)
+
+	return NewLogger(opts...)
+}
+
+// NewGELFLogger creates and returns an instance of Logger which will send GELF messages to the specified output.
+// The log level is set to TraceLevel (log everything) and color logging is disabled.
+// Any number of additional functional options can be passed to this method and they will be applied on creation.
+// These additional options will override any of the settings mentioned above.
+// You can read more information on functional options on the PLog wiki: https://github.com/pd93/plog/wiki/Functional-Options.
+func NewGELFLogger(output *GELFOutput, opts ...LoggerOption) *Logger {
+
+	// Append the given options to the default GELF logger
+	opts = append([]LoggerOption{
+		WithOutput(output),
+		WithLogLevel(TraceLevel),
+		WithRecordFormatter(formatters.GELF),
+		WithColorLogging(false),
+	}, opts...)
+
+	return NewLogger(opts...)
+}
+
+// NewSyslogLogger creates and returns an instance of Logger which will send syslog messages to the specified output.
+// The log level is set to TraceLevel (log everything), color logging is disabled and messages are formatted as RFC 5424.
+// Any number of additional functional options can be passed to this method and they will be applied on creation.
+// These additional options will override any of the settings mentioned above.
+// You can read more information on functional options on the PLog wiki: https://github.com/pd93/plog/wiki/Functional-Options.
+func NewSyslogLogger(output *SyslogOutput, opts ...LoggerOption) *Logger {
+
+	// Append the given options to the default syslog logger
+	opts = append([]LoggerOption{
+		WithOutput(output),
+		WithLogLevel(TraceLevel),
+		WithRecordFormatter(formatters.RFC5424),
 		WithColorLogging(false),
 	}, opts...)
 
@@ -132,9 +203,22 @@ func WithLogLevel(logLevel LogLevel) LoggerOption {
 // WithFormatter will return a function that sets the formatter of a logger.
 // PLog includes several formatters for convenience (See `formatters` subpackage).
 // Users can also provide a their own function if they want a custom format.
+// The formatter is always called with the pre-rendered timestamp, log level and tags, even if it is one of the bundled formatters (e.g. `formatters.JSON`).
+// Use `WithRecordFormatter` with a bundled record formatter (e.g. `formatters.JSONRecord`) to format the structured record without rendering it into strings first.
 func WithFormatter(formatter Formatter) LoggerOption {
 	return func(logger *Logger) {
 		logger.formatter = formatter
+		logger.recordFormatter = formatter
+	}
+}
+
+// WithRecordFormatter will return a function that sets a formatter which receives the structured record of each log.
+// Record formatters receive the raw timestamp, the uncolored log level and tags, and a render context with the logger's settings.
+// Most formatters in the `formatters` subpackage are record formatters (e.g. `formatters.GELF` or `formatters.NewJSON()`).
+func WithRecordFormatter(recordFormatter RecordFormatter) LoggerOption {
+	return func(logger *Logger) {
+		logger.formatter = nil
+		logger.recordFormatter = recordFormatter
 	}
 }
 
@@ -147,10 +231,50 @@ func WithTimestampFormat(timestampFormat string) LoggerOption {
 	}
 }
 
+// WithTimestampLocation will return a function that sets the time zone used to render timestamps.
+// By default, timestamps are rendered in whatever location is returned by the logger's clock (usually local time).
+// Pass `time.UTC` to force all timestamps into UTC.
+func WithTimestampLocation(timestampLocation *time.Location) LoggerOption {
+	return func(logger *Logger) {
+		logger.timestampLocation = timestampLocation
+	}
+}
+
+// WithTimestampPrecision will return a function that sets the precision of a logger's timestamps.
+// Timestamps are truncated to a multiple of the given duration (e.g. `time.Millisecond`).
+// If the precision is set to 0, timestamps are not truncated.
+func WithTimestampPrecision(timestampPrecision time.Duration) LoggerOption {
+	return func(logger *Logger) {
+		logger.timestampPrecision = timestampPrecision
+	}
+}
+
+// WithTimestampEncoding will return a function that sets how a logger's timestamps are encoded.
+// The default encoding is `formatters.FormattedTimestamp` which uses the timestamp format.
+// Unix encodings are written as numbers by the JSON formatter.
+// Elapsed and delta encodings are useful for profiling short-lived programs.
+func WithTimestampEncoding(timestampEncoding formatters.TimestampEncoding) LoggerOption {
+	return func(logger *Logger) {
+		logger.timestampEncoding = timestampEncoding
+	}
+}
+
 // WithColorLogging will return a function that sets the color logging flag of a logger.
+// This overrides automatic color detection.
 func WithColorLogging(colorLogging bool) LoggerOption {
 	return func(logger *Logger) {
 		logger.colorLogging = colorLogging
+		logger.autoColorLogging = false
+	}
+}
+
+// WithAutoColorLogging will return a function that makes a logger detect whether or not to use color.
+// This is the default behaviour. Color is used when the output is a terminal unless overridden by the environment.
+// Setting NO_COLOR or TERM=dumb disables color and setting FORCE_COLOR or CLICOLOR_FORCE enables it.
+// Detection is repeated whenever the logger's options are changed (e.g. when a new output is set).
+func WithAutoColorLogging() LoggerOption {
+	return func(logger *Logger) {
+		logger.autoColorLogging = true
 	}
 }
 
@@ -168,6 +292,43 @@ func WithTagColorMap(tagColorMap TagColorMap) LoggerOption {
 	}
 }
 
+// WithTheme will return a function that sets the styles used by a logger.
+// This replaces the logger's log level and tag color maps with the theme's.
+// PLog includes several themes (e.g. `SolarizedTheme()`) and themes can also be loaded from JSON using `LoadTheme()`.
+func WithTheme(theme Theme) LoggerOption {
+	return func(logger *Logger) {
+		logger.theme = theme
+		logger.logLevelColorMap = theme.LogLevels
+		logger.tagColorMap = theme.Tags
+	}
+}
+
+// WithLevelStyling will return a function that sets which parts of a log are styled using the log level color map.
+// Parts can be combined (e.g. `WithLevelStyling(LevelStyledTimestamp | LevelStyledMessage)`).
+// Styles set for specific log levels in the logger's theme take precedence. Only `formatters.Text` uses these styles.
+func WithLevelStyling(levelStyling LevelStyling) LoggerOption {
+	return func(logger *Logger) {
+		logger.levelStyling = levelStyling
+	}
+}
+
+// WithAutoTagColors will return a function that sets whether or not a logger colors unmapped tags automatically.
+// When enabled, any tag that is not in the tag color map is given a color based on a hash of its name, so the same tag always has the same color.
+// Colors that look similar to the log level colors are not used. Tags in the tag color map keep their colors.
+func WithAutoTagColors(autoTagColors bool) LoggerOption {
+	return func(logger *Logger) {
+		logger.autoTagColors = autoTagColors
+	}
+}
+
+// WithBackground will return a function that sets the background color of a logger's terminal.
+// Automatic tag colors will avoid colors that are hard to read on the background.
+func WithBackground(background Background) LoggerOption {
+	return func(logger *Logger) {
+		logger.background = background
+	}
+}
+
 // WithGlobalLogging controls whether or not to include this logger when printing via the global logger map.
 // If set to false, this logger will only be written to when manually called on the logger itself.
 func WithGlobalLogging(globalLogging bool) LoggerOption {
@@ -176,6 +337,93 @@ func WithGlobalLogging(globalLogging bool) LoggerOption {
 	}
 }
 
+// WithHook will return a function that adds a hook to a logger.
+// Hooks are called with every log that passes the logger's log level check.
+// Any number of hooks can be added to a logger and they will be called in the order they were added.
+func WithHook(hook Hook) LoggerOption {
+	return func(logger *Logger) {
+		logger.hooks = append(logger.hooks, hook)
+	}
+}
+
+// WithTimerLogLevel will return a function that sets the log level used by a logger's timers.
+// The default timer log level is InfoLevel.
+func WithTimerLogLevel(timerLogLevel LogLevel) LoggerOption {
+	return func(logger *Logger) {
+		logger.timerLogLevel = timerLogLevel
+	}
+}
+
+// WithTimerThreshold will return a function that sets the duration after which a logger's timers are logged at warn level.
+// If the threshold is set to 0, timers are always logged at the timer log level.
+func WithTimerThreshold(timerThreshold time.Duration) LoggerOption {
+	return func(logger *Logger) {
+		logger.timerThreshold = timerThreshold
+	}
+}
+
+// WithClock will return a function that sets the clock used to timestamp logs.
+// The default clock is `time.Now`. Setting a fake clock (See `mocks.Clock`) allows log output to be tested deterministically.
+// Elapsed and delta timestamps are measured from the time the clock is set.
+func WithClock(clock Clock) LoggerOption {
+	return func(logger *Logger) {
+		logger.clock = clock
+
+		// Restart the clock for elapsed timestamps
+		logger.start = clock()
+		logger.prev = logger.start
+	}
+}
+
+// WithRedactor will return a function that sets the redactor used to mask sensitive information.
+// Variables, fields and tags are redacted before they are passed to any hooks or the formatter.
+// The arguments of formatting functions (e.g. `Infof`) are redacted before the message is rendered.
+func WithRedactor(redactor *Redactor) LoggerOption {
+	return func(logger *Logger) {
+		logger.redactor = redactor
+	}
+}
+
+// WithSanitization will return a function that sets whether or not a logger sanitizes its variables and tags.
+// When enabled, control characters (including newlines) are escaped and escape sequences are stripped from variables and tags.
+// This stops user input from forging log lines or changing the colors of the terminal.
+// Strings colored with the Color function keep their colors if color logging is enabled. Any other string that contains escape sequences is treated as user input and stripped.
+func WithSanitization(sanitize bool) LoggerOption {
+	return func(logger *Logger) {
+		logger.sanitize = sanitize
+	}
+}
+
+// WithMultiline will return a function that sets whether or not a sanitizing logger renders multi-line variables.
+// When enabled, newlines are kept and each new line is indented. Only line-based formatters (e.g. `formatters.Text`) use this setting.
+// `formatters.CSV`, `formatters.JSON` and `formatters.Logfmt` always write one log per line.
+func WithMultiline(multiline bool) LoggerOption {
+	return func(logger *Logger) {
+		logger.multiline = multiline
+	}
+}
+
+// WithMarkup will return a function that sets whether or not a logger renders markup in its format strings and message templates.
+// Markup tags are written as "<green>OK</green>" or "{bold}...{/}" and can use any attribute name (See `ParseAttribute`).
+// When color logging is enabled, `formatters.Text` replaces the tags with colors. Otherwise the tags are removed.
+// Markup is only read from format strings (e.g. `Infof`) and message templates (e.g. `Infot`), never from values.
+// This means that user data such as "<red>" is always written literally.
+// Brackets can be escaped with a backslash (e.g. "\\<green>") to write them literally.
+func WithMarkup(markup bool) LoggerOption {
+	return func(logger *Logger) {
+		logger.markup = markup
+	}
+}
+
+// WithCaller will return a function that sets whether or not a logger records the location of the code that wrote each log.
+// The location is available to formatters as `Record.Caller` (e.g. `formatters.ECS` writes it as `log.origin.*`).
+// Finding the caller takes time on every log, so this is disabled by default.
+func WithCaller(caller bool) LoggerOption {
+	return func(logger *Logger) {
+		logger.caller = caller
+	}
+}
+
 //
 // Options Setter
 //
@@ -187,6 +435,15 @@ func (logger *Logger) Options(opts ...LoggerOption) {
 	for _, opt := range opts {
 		opt(logger)
 	}
+
+	// Detect whether or not to use color for the current output
+	if logger.autoColorLogging {
+		logger.colorLogging = detectColorLogging(logger.output)
+	}
+
+	// The colors may have changed, so rebuild the tag palette and color map styles when they are next needed
+	logger.tagPalette = tagPalette{}
+	logger.colorMapStyles = colorMapStyles{}
 }
 
 //
@@ -203,21 +460,47 @@ func (logger *Logger) LogLevel() LogLevel {
 	return logger.logLevel
 }
 
-// Formatter will return the logger's current log format.
+// Formatter will return the formatter set using WithFormatter.
+// If the logger has a record formatter instead, nil is returned.
 func (logger *Logger) Formatter() Formatter {
 	return logger.formatter
 }
 
+// RecordFormatter will return the formatter that writes the logger's logs.
+func (logger *Logger) RecordFormatter() RecordFormatter {
+	return logger.recordFormatter
+}
+
 // TimestampFormat will return the logger's current timestamp format.
 func (logger *Logger) TimestampFormat() string {
 	return logger.timestampFormat
 }
 
+// TimestampLocation will return the time zone used to render the logger's timestamps.
+func (logger *Logger) TimestampLocation() *time.Location {
+	return logger.timestampLocation
+}
+
+// TimestampPrecision will return the precision of the logger's timestamps.
+func (logger *Logger) TimestampPrecision() time.Duration {
+	return logger.timestampPrecision
+}
+
+// TimestampEncoding will return how the logger's timestamps are encoded.
+func (logger *Logger) TimestampEncoding() formatters.TimestampEncoding {
+	return logger.timestampEncoding
+}
+
 // ColorLogging will return whether or not color logging is enabled.
 func (logger *Logger) ColorLogging() bool {
 	return logger.colorLogging
 }
 
+// AutoColorLogging will return whether or not the logger detects whether or not to use color.
+func (logger *Logger) AutoColorLogging() bool {
+	return logger.autoColorLogging
+}
+
 // LogLevelColorMap will return the logger's text attributes for each log level.
 func (logger *Logger) LogLevelColorMap() LogLevelColorMap {
 	return logger.logLevelColorMap
@@ -228,28 +511,141 @@ func (logger *Logger) TagColorMap() TagColorMap {
 	return logger.tagColorMap
 }
 
+// Theme will return the styles used by the logger.
+// The theme's log level and tag color maps are the logger's current color maps.
+func (logger *Logger) Theme() Theme {
+	theme := logger.theme
+	theme.LogLevels = logger.logLevelColorMap
+	theme.Tags = logger.tagColorMap
+	return theme
+}
+
+// LevelStyling will return the parts of a log that are styled using the log level color map.
+func (logger *Logger) LevelStyling() LevelStyling {
+	return logger.levelStyling
+}
+
+// AutoTagColors will return whether or not the logger colors unmapped tags automatically.
+func (logger *Logger) AutoTagColors() bool {
+	return logger.autoTagColors
+}
+
+// Background will return the background color of the logger's terminal.
+func (logger *Logger) Background() Background {
+	return logger.background
+}
+
+// GlobalLogging will return whether or not the logger is written to by the global logging functions.
+func (logger *Logger) GlobalLogging() bool {
+	return logger.globalLogging
+}
+
+// Hooks will return the list of hooks that are called when the logger writes a log.
+func (logger *Logger) Hooks() []Hook {
+	return logger.hooks
+}
+
+// TimerLogLevel will return the log level used by the logger's timers.
+func (logger *Logger) TimerLogLevel() LogLevel {
+	return logger.timerLogLevel
+}
+
+// TimerThreshold will return the duration after which the logger's timers are logged at warn level.
+func (logger *Logger) TimerThreshold() time.Duration {
+	return logger.timerThreshold
+}
+
+// Clock will return the clock used to timestamp logs.
+func (logger *Logger) Clock() Clock {
+	return logger.clock
+}
+
+// Redactor will return the redactor used to mask sensitive information.
+func (logger *Logger) Redactor() *Redactor {
+	return logger.redactor
+}
+
+// Sanitization will return whether or not the logger sanitizes its variables.
+func (logger *Logger) Sanitization() bool {
+	return logger.sanitize
+}
+
+// Multiline will return whether or not the logger renders multi-line variables as indented continuation lines.
+func (logger *Logger) Multiline() bool {
+	return logger.multiline
+}
+
+// Markup will return whether or not the logger renders markup in its format strings and message templates.
+func (logger *Logger) Markup() bool {
+	return logger.markup
+}
+
+// Caller will return whether or not the logger records the location of the code that wrote each log.
+func (logger *Logger) Caller() bool {
+	return logger.caller
+}
+
+// Enabled will return whether or not the logger will write logs at the given log level.
+// This can be used to avoid doing expensive work for logs that will never be written.
+func (logger *Logger) Enabled(logLevel LogLevel) bool {
+	return logger.logLevel >= logLevel
+}
+
+// check will return whether or not the logger will write logs at the given log level.
+// Logs that will not be written are counted as filtered.
+func (logger *Logger) check(logLevel LogLevel) bool {
+	if !logger.Enabled(logLevel) {
+		atomic.AddUint64(&logger.counters.filtered, 1)
+		return false
+	}
+	return true
+}
+
 //
 // Fatal logging (Level 1)
 //
 
 // Fatal will print a fatal error message.
 func (logger *Logger) Fatal(err error) {
-	logger.write(newLogf(FatalLevel, "%+v", err))
+	if logger.check(FatalLevel) {
+		logger.write(newLogf(FatalLevel, "%+v", err))
+	}
 }
 
 // Fatalf will print a formatted, non-fatal error message.
 func (logger *Logger) Fatalf(format string, err error) {
-	logger.write(newLogf(FatalLevel, format, err))
+	if logger.check(FatalLevel) {
+		logger.write(newLogf(FatalLevel, format, err))
+	}
 }
 
 // TFatal will print a fatal error message and meta-tag the log.
 func (logger *Logger) TFatal(tags Tags, err error) {
-	logger.write(newTLogf(FatalLevel, tags, "%+v", err))
+	if logger.check(FatalLevel) {
+		logger.write(newTLogf(FatalLevel, tags, "%+v", err))
+	}
 }
 
 // TFatalf will print a formatted, fatal error message and meta-tag the log.
 func (logger *Logger) TFatalf(tags Tags, format string, err error) {
-	logger.write(newTLogf(FatalLevel, tags, format, err))
+	if logger.check(FatalLevel) {
+		logger.write(newTLogf(FatalLevel, tags, format, err))
+	}
+}
+
+// Fatalt will print a fatal error message using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func (logger *Logger) Fatalt(template string, variables ...interface{}) {
+	if logger.check(FatalLevel) {
+		logger.write(newLogt(FatalLevel, template, variables...))
+	}
+}
+
+// TFatalt will print a fatal error message using a message template and meta-tag the log.
+func (logger *Logger) TFatalt(tags Tags, template string, variables ...interface{}) {
+	if logger.check(FatalLevel) {
+		logger.write(newTLogt(FatalLevel, tags, template, variables...))
+	}
 }
 
 //
@@ -258,22 +654,45 @@ func (logger *Logger) TFatalf(tags Tags, format string, err error) {
 
 // Error will print a non-fatal error message.
 func (logger *Logger) Error(err error) {
-	logger.write(newLogf(ErrorLevel, "%+v", err))
+	if logger.check(ErrorLevel) {
+		logger.write(newLogf(ErrorLevel, "%+v", err))
+	}
 }
 
 // Errorf will print a formatted, non-fatal error message.
 func (logger *Logger) Errorf(format string, err error) {
-	logger.write(newLogf(ErrorLevel, format, err))
+	if logger.check(ErrorLevel) {
+		logger.write(newLogf(ErrorLevel, format, err))
+	}
 }
 
 // TError will print a non-fatal error message and meta-tag the log.
 func (logger *Logger) TError(tags Tags, err error) {
-	logger.write(newTLogf(ErrorLevel, tags, "%+v", err))
+	if logger.check(ErrorLevel) {
+		logger.write(newTLogf(ErrorLevel, tags, "%+v", err))
+	}
 }
 
 // TErrorf will print a formatted, non-fatal error message and meta-tag the log.
 func (logger *Logger) TErrorf(tags Tags, format string, err error) {
-	logger.write(newTLogf(ErrorLevel, tags, format, err))
+	if logger.check(ErrorLevel) {
+		logger.write(newTLogf(ErrorLevel, tags, format, err))
+	}
+}
+
+// Errort will print a non-fatal error message using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func (logger *Logger) Errort(template string, variables ...interface{}) {
+	if logger.check(ErrorLevel) {
+		logger.write(newLogt(ErrorLevel, template, variables...))
+	}
+}
+
+// TErrort will print a non-fatal error message using a message template and meta-tag the log.
+func (logger *Logger) TErrort(tags Tags, template string, variables ...interface{}) {
+	if logger.check(ErrorLevel) {
+		logger.write(newTLogt(ErrorLevel, tags, template, variables...))
+	}
 }
 
 //
@@ -282,22 +701,45 @@ func (logger *Logger) TErrorf(tags Tags, format string, err error) {
 
 // Warn will print any number of variables at warn level.
 func (logger *Logger) Warn(variables ...interface{}) {
-	logger.write(newLog(WarnLevel, variables...))
+	if logger.check(WarnLevel) {
+		logger.write(newLog(WarnLevel, variables...))
+	}
 }
 
 // Warnf will print a formatted message at warn level.
 func (logger *Logger) Warnf(format string, variables ...interface{}) {
-	logger.write(newLogf(WarnLevel, format, variables...))
+	if logger.check(WarnLevel) {
+		logger.write(newLogf(WarnLevel, format, variables...))
+	}
 }
 
 // TWarn will print any number of variables at warn level and meta-tag the log.
 func (logger *Logger) TWarn(tags Tags, variables ...interface{}) {
-	logger.write(newTLog(WarnLevel, tags, variables...))
+	if logger.check(WarnLevel) {
+		logger.write(newTLog(WarnLevel, tags, variables...))
+	}
 }
 
 // TWarnf will print a formatted message at warn level and meta-tag the log.
 func (logger *Logger) TWarnf(tags Tags, format string, variables ...interface{}) {
-	logger.write(newTLogf(WarnLevel, tags, format, variables...))
+	if logger.check(WarnLevel) {
+		logger.write(newTLogf(WarnLevel, tags, format, variables...))
+	}
+}
+
+// Warnt will print a warning using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func (logger *Logger) Warnt(template string, variables ...interface{}) {
+	if logger.check(WarnLevel) {
+		logger.write(newLogt(WarnLevel, template, variables...))
+	}
+}
+
+// TWarnt will print a warning using a message template and meta-tag the log.
+func (logger *Logger) TWarnt(tags Tags, template string, variables ...interface{}) {
+	if logger.check(WarnLevel) {
+		logger.write(newTLogt(WarnLevel, tags, template, variables...))
+	}
 }
 
 //
@@ -306,22 +748,45 @@ func (logger *Logger) TWarnf(tags Tags, format string, variables ...interface{})
 
 // Info will print any number of variables at info level.
 func (logger *Logger) Info(variables ...interface{}) {
-	logger.write(newLog(InfoLevel, variables...))
+	if logger.check(InfoLevel) {
+		logger.write(newLog(InfoLevel, variables...))
+	}
 }
 
 // Infof will print a formatted message at info level.
 func (logger *Logger) Infof(format string, variables ...interface{}) {
-	logger.write(newLogf(InfoLevel, format, variables...))
+	if logger.check(InfoLevel) {
+		logger.write(newLogf(InfoLevel, format, variables...))
+	}
 }
 
 // TInfo will print any number of variables at info level and meta-tag the log.
 func (logger *Logger) TInfo(tags Tags, variables ...interface{}) {
-	logger.write(newTLog(InfoLevel, tags, variables...))
+	if logger.check(InfoLevel) {
+		logger.write(newTLog(InfoLevel, tags, variables...))
+	}
 }
 
 // TInfof will print a formatted message at info level and meta-tag the log.
 func (logger *Logger) TInfof(tags Tags, format string, variables ...interface{}) {
-	logger.write(newTLogf(InfoLevel, tags, format, variables...))
+	if logger.check(InfoLevel) {
+		logger.write(newTLogf(InfoLevel, tags, format, variables...))
+	}
+}
+
+// Infot will print a message at info level using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func (logger *Logger) Infot(template string, variables ...interface{}) {
+	if logger.check(InfoLevel) {
+		logger.write(newLogt(InfoLevel, template, variables...))
+	}
+}
+
+// TInfot will print a message at info level using a message template and meta-tag the log.
+func (logger *Logger) TInfot(tags Tags, template string, variables ...interface{}) {
+	if logger.check(InfoLevel) {
+		logger.write(newTLogt(InfoLevel, tags, template, variables...))
+	}
 }
 
 //
@@ -330,22 +795,45 @@ func (logger *Logger) TInfof(tags Tags, format string, variables ...interface{})
 
 // Debug will print any number of variables at debug level.
 func (logger *Logger) Debug(variables ...interface{}) {
-	logger.write(newLog(DebugLevel, variables...))
+	if logger.check(DebugLevel) {
+		logger.write(newLog(DebugLevel, variables...))
+	}
 }
 
 // Debugf will print a formatted message at debug level.
 func (logger *Logger) Debugf(format string, variables ...interface{}) {
-	logger.write(newLogf(DebugLevel, format, variables...))
+	if logger.check(DebugLevel) {
+		logger.write(newLogf(DebugLevel, format, variables...))
+	}
 }
 
 // TDebug will print any number of variables at debug level and meta-tag the log.
 func (logger *Logger) TDebug(tags Tags, variables ...interface{}) {
-	logger.write(newTLog(DebugLevel, tags, variables...))
+	if logger.check(DebugLevel) {
+		logger.write(newTLog(DebugLevel, tags, variables...))
+	}
 }
 
 // TDebugf will print a formatted message at debug level and meta-tag the log.
 func (logger *Logger) TDebugf(tags Tags, format string, variables ...interface{}) {
-	logger.write(newTLogf(DebugLevel, tags, format, variables...))
+	if logger.check(DebugLevel) {
+		logger.write(newTLogf(DebugLevel, tags, format, variables...))
+	}
+}
+
+// Debugt will print a message at debug level using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func (logger *Logger) Debugt(template string, variables ...interface{}) {
+	if logger.check(DebugLevel) {
+		logger.write(newLogt(DebugLevel, template, variables...))
+	}
+}
+
+// TDebugt will print a message at debug level using a message template and meta-tag the log.
+func (logger *Logger) TDebugt(tags Tags, template string, variables ...interface{}) {
+	if logger.check(DebugLevel) {
+		logger.write(newTLogt(DebugLevel, tags, template, variables...))
+	}
 }
 
 //
@@ -354,60 +842,231 @@ func (logger *Logger) TDebugf(tags Tags, format string, variables ...interface{}
 
 // Trace will print any number of variables at debug level.
 func (logger *Logger) Trace(variables ...interface{}) {
-	logger.write(newLog(TraceLevel, variables...))
+	if logger.check(TraceLevel) {
+		logger.write(newLog(TraceLevel, variables...))
+	}
 }
 
 // Tracef will print a formatted message at debug level.
 func (logger *Logger) Tracef(format string, variables ...interface{}) {
-	logger.write(newLogf(TraceLevel, format, variables...))
+	if logger.check(TraceLevel) {
+		logger.write(newLogf(TraceLevel, format, variables...))
+	}
 }
 
 // TTrace will print any number of variables at trace level and meta-tag the log.
 func (logger *Logger) TTrace(tags Tags, variables ...interface{}) {
-	logger.write(newTLog(TraceLevel, tags, variables...))
+	if logger.check(TraceLevel) {
+		logger.write(newTLog(TraceLevel, tags, variables...))
+	}
 }
 
 // TTracef will print a formatted message at trace level and meta-tag the log.
 func (logger *Logger) TTracef(tags Tags, format string, variables ...interface{}) {
-	logger.write(newTLogf(TraceLevel, tags, format, variables...))
+	if logger.check(TraceLevel) {
+		logger.write(newTLogf(TraceLevel, tags, format, variables...))
+	}
+}
+
+// Tracet will print a message at trace level using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func (logger *Logger) Tracet(template string, variables ...interface{}) {
+	if logger.check(TraceLevel) {
+		logger.write(newLogt(TraceLevel, template, variables...))
+	}
+}
+
+// TTracet will print a message at trace level using a message template and meta-tag the log.
+func (logger *Logger) TTracet(tags Tags, template string, variables ...interface{}) {
+	if logger.check(TraceLevel) {
+		logger.write(newTLogt(TraceLevel, tags, template, variables...))
+	}
 }
 
 //
 // Writer
 //
 
-// write will add a log message to the logger.
+// write will add a log message to the logger and release the log once it has been written.
 func (logger *Logger) write(log *Log) {
+	logger.emit(log)
+	log.release()
+}
+
+// emit will format the log and write it to the logger's output.
+func (logger *Logger) emit(log *Log) {
 
 	// Check if we need to log this message or not
-	if logger.logLevel >= log.logLevel {
+	if logger.Enabled(log.logLevel) {
 
-		var err error
+		logger.mutex.Lock()
+		defer logger.mutex.Unlock()
 
-		// Render each component of the log
-		timestamp := log.timestamp.Format(logger.timestampFormat)
-		logLevel := log.logLevel.String(logger.colorLogging, logger.logLevelColorMap)
-		tags := log.tags.String(logger.colorLogging, logger.tagColorMap)
+		// Timestamp the log using the logger's clock
+		log.timestamp = logger.clock()
+
+		// Now that we know the log will be written, evaluate any lazy values
+		log.resolve()
+
+		entry := newEntry()
+		defer entry.release()
+
+		// Redact the log and render any formatted message without modifying the log, since it may be shared with other loggers
+		entry.prepare(log, logger.redactor, logger.markup)
+		log = &entry.log
+
+		// Call any hooks
+		for _, hook := range logger.hooks {
+			hook(log)
+		}
+
+		// Build the record
+		entry.record.Timestamp = logger.timestamp(log.timestamp)
+		entry.record.Level = int(log.logLevel)
+		entry.record.LogLevel = log.logLevel.String(false, nil)
+		entry.record.Template = log.template
+		entry.record.Variables = entry.variablesWithMarkup()
+		entry.record.Err = entry.err
+		entry.record.Tags = entry.record.Tags[:0]
+		for _, tag := range log.tags {
+			entry.record.Tags = append(entry.record.Tags, string(tag))
+		}
+		entry.record.Caller = formatters.Caller{}
+		if logger.caller {
+			entry.record.Caller = findCaller()
+		}
+
+		// Build the render context
+		entry.ctx.ColorLogging = logger.colorLogging
+		entry.ctx.TimestampFormat = logger.timestampFormat
+		entry.ctx.TimestampLocation = logger.timestampLocation
+		entry.ctx.TimestampPrecision = logger.timestampPrecision
+		entry.ctx.TimestampEncoding = logger.timestampEncoding
+		entry.ctx.Sanitize = logger.sanitize
+		entry.ctx.Multiline = logger.multiline
+		entry.ctx.Markup, entry.ctx.StyleResolver = nil, nil
+		if logger.markup {
+			entry.ctx.Markup = resolveMarkup
+		}
+		if logger.colorLogging {
+			entry.ctx.StyleResolver = resolveMarkup
+		}
+		entry.ctx.LogLevelStyle = log.logLevel.style(logger.colorLogging, logger.logLevelColorMap)
+		entry.ctx.TagStyles = entry.ctx.TagStyles[:0]
+		palette := logger.palette()
+		for _, tag := range log.tags {
+			entry.ctx.TagStyles = append(entry.ctx.TagStyles, tag.style(logger.colorLogging, logger.tagColorMap, palette, logger.theme.Tag))
+		}
+		entry.ctx.LogLevelColorMap, entry.ctx.TagColorMap = nil, nil
+		if logger.colorLogging {
+			styles := logger.styles()
+			entry.ctx.LogLevelColorMap, entry.ctx.TagColorMap = styles.logLevels, styles.tags
+		}
+		entry.ctx.TimestampStyle, entry.ctx.MessageStyle, entry.ctx.SeparatorStyle, entry.ctx.LineStyle = "", "", "", ""
+		if logger.colorLogging {
+			entry.ctx.TimestampStyle = logger.levelStyle(log.logLevel, logger.theme.Timestamps, LevelStyledTimestamp, logger.theme.Timestamp)
+			entry.ctx.MessageStyle = logger.levelStyle(log.logLevel, logger.theme.Messages, LevelStyledMessage, logger.theme.Message)
+			entry.ctx.SeparatorStyle = newStyle(logger.theme.Separator)
+			entry.ctx.LineStyle = logger.levelStyle(log.logLevel, logger.theme.Lines, LevelStyledLine, nil)
+		}
 
 		// Fetch the output
-		output, err := logger.formatter(timestamp, logLevel, log.variables, tags)
+		output, err := logger.recordFormatter.Format(entry.buffer[:0], &entry.record, &entry.ctx)
 		if err != nil {
-			panic(err)
+			logger.reportError(err)
+			return
 		}
 
-		// Should we print with a new line or not?
-		if log.newLine {
-			_, err = fmt.Fprintln(logger.output, output)
-		} else {
-			_, err = fmt.Fprint(logger.output, output)
-		}
+		// Every record ends with a newline so that line-based formats keep one record per line
+		output = append(output, '\n')
+		entry.buffer = output
 
 		// Print the message to the output writer
+		n, err := logger.output.Write(output)
+		atomic.AddUint64(&logger.counters.bytes, uint64(n))
 		if err != nil {
-			// TODO: Handle this error better somehow
-			panic(err)
+			logger.reportError(err)
+			return
+		}
+
+		// Count the log
+		if log.logLevel >= FatalLevel && log.logLevel <= TraceLevel {
+			atomic.AddUint64(&logger.counters.records[log.logLevel], 1)
 		}
 	}
+}
 
-	return
+// levelStyle will return the style of part of a log at the given log level.
+// Styles are chosen from the theme's map for that part first, then the log level color map (if level styling is enabled for that part).
+// Otherwise the default attributes are used.
+func (logger *Logger) levelStyle(logLevel LogLevel, levels LogLevelColorMap, part LevelStyling, defaultAttributes []Attribute) formatters.Style {
+
+	if attributes, ok := levels[logLevel]; ok {
+		return newStyle(attributes)
+	}
+
+	if attributes, ok := logger.logLevelColorMap[logLevel]; ok && logger.levelStyling&part != 0 {
+		return newStyle(attributes)
+	}
+
+	return newStyle(defaultAttributes)
+}
+
+// palette will return the colors for unmapped tags, or nil if they should not be colored automatically.
+// The palette is rebuilt if the color profile has changed since it was last built.
+func (logger *Logger) palette() *tagPalette {
+
+	if !logger.autoTagColors || !logger.colorLogging {
+		return nil
+	}
+
+	if profile := CurrentColorProfile(); !logger.tagPalette.built || logger.tagPalette.profile != profile {
+		logger.tagPalette = newTagPalette(profile, logger.logLevelColorMap, logger.background)
+	}
+
+	return &logger.tagPalette
+}
+
+// styles will return the logger's color maps rendered as styles.
+// The styles are rebuilt if the color profile has changed since they were last built.
+func (logger *Logger) styles() *colorMapStyles {
+
+	if profile := CurrentColorProfile(); !logger.colorMapStyles.built || logger.colorMapStyles.profile != profile {
+		logger.colorMapStyles = newColorMapStyles(profile, logger.logLevelColorMap, logger.tagColorMap)
+	}
+
+	return &logger.colorMapStyles
+}
+
+// reportError will count an error that stopped a log from being written and print it to stderr.
+// Logging should never crash the program, so the log is dropped and the error is not returned.
+func (logger *Logger) reportError(err error) {
+	atomic.AddUint64(&logger.counters.errors, 1)
+	fmt.Fprintf(os.Stderr, "plog: %v\n", err)
+}
+
+// timestamp will apply the logger's timestamp settings to the given time.
+// It also records the time so that the next log can calculate its delta.
+func (logger *Logger) timestamp(t time.Time) formatters.Timestamp {
+
+	timestamp := formatters.Timestamp{
+		Time:     t,
+		Format:   logger.timestampFormat,
+		Encoding: logger.timestampEncoding,
+		Elapsed:  t.Sub(logger.start),
+		Delta:    t.Sub(logger.prev),
+	}
+	logger.prev = t
+
+	// Convert the time to the correct time zone
+	if logger.timestampLocation != nil {
+		timestamp.Time = timestamp.Time.In(logger.timestampLocation)
+	}
+
+	// Truncate the time to the correct precision
+	if logger.timestampPrecision > 0 {
+		timestamp.Time = timestamp.Time.Truncate(logger.timestampPrecision)
+	}
+
+	return timestamp
 }
@@ -0,0 +1,481 @@
+package plog
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pd93/plog/formatters"
+	"github.com/pd93/plog/mocks"
+)
+
+func TestLoggerClock(t *testing.T) {
+
+	// Expected output
+	const expected = "2006-01-02T15:04:05Z [INFO] [#tag1] Test string\n2006-01-02T15:04:06Z [WARN] Test string 123\n"
+
+	// Create a logger with a fake clock
+	var buffer bytes.Buffer
+	clock := mocks.NewClock(mocks.Now())
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithColorLogging(false),
+		WithClock(clock.Now),
+	)
+
+	// Write some logs, advancing the clock in between
+	logger.TInfo(Tags{"tag1"}, "Test string")
+	clock.Advance(time.Second)
+	logger.Warn("Test string", 123)
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+// timestampFormatter is a simple formatting function that only writes the pre-rendered timestamp.
+var timestampFormatter = Formatter(func(timestamp, logLevel string, variables []interface{}, tags []string) (string, error) {
+	return timestamp, nil
+})
+
+type timestampOptionsTest struct {
+	opts     []LoggerOption
+	expected string
+}
+
+func TestLoggerTimestampOptions(t *testing.T) {
+
+	tests := []timestampOptionsTest{
+		{
+			[]LoggerOption{WithTimestampLocation(time.FixedZone("EST", -5*60*60))},
+			"2006-01-02T10:04:05-05:00 [INFO] Test string\n2006-01-02T10:04:06-05:00 [INFO] Test string\n",
+		},
+		{
+			[]LoggerOption{WithTimestampFormat(time.RFC3339Nano), WithTimestampPrecision(time.Millisecond)},
+			"2006-01-02T15:04:05.123Z [INFO] Test string\n2006-01-02T15:04:06.123Z [INFO] Test string\n",
+		},
+		{
+			[]LoggerOption{WithTimestampEncoding(formatters.ElapsedTimestamp)},
+			"+0s [INFO] Test string\n+1s [INFO] Test string\n",
+		},
+		{
+			[]LoggerOption{WithTimestampEncoding(formatters.DeltaTimestamp)},
+			"+0s [INFO] Test string\n+1s [INFO] Test string\n",
+		},
+		{
+			[]LoggerOption{WithTimestampEncoding(formatters.UnixMillis), WithRecordFormatter(formatters.JSONRecord)},
+			`{"timestamp":1136214245123,"logLevel":"INFO","variables":["Test string"]}` + "\n" +
+				`{"timestamp":1136214246123,"logLevel":"INFO","variables":["Test string"]}` + "\n",
+		},
+		{
+			[]LoggerOption{WithTimestampEncoding(formatters.UnixMillis), WithFormatter(timestampFormatter)},
+			"1136214245123\n1136214246123\n",
+		},
+		{
+			[]LoggerOption{WithTimestampLocation(time.FixedZone("EST", -5*60*60)), WithTimestampFormat(time.RFC3339Nano), WithTimestampPrecision(time.Millisecond), WithFormatter(timestampFormatter)},
+			"2006-01-02T10:04:05.123-05:00\n2006-01-02T10:04:06.123-05:00\n",
+		},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Create a logger with a fake clock
+		var buffer bytes.Buffer
+		clock := mocks.NewClock(time.Date(2006, 01, 02, 15, 04, 05, 123456789, time.UTC))
+		logger := NewLogger(append([]LoggerOption{
+			WithOutput(&buffer),
+			WithColorLogging(false),
+			WithClock(clock.Now),
+		}, test.opts...)...)
+
+		// Write two logs, one second apart
+		logger.Info("Test string")
+		clock.Advance(time.Second)
+		logger.Info("Test string")
+
+		// Check if the output is correct
+		if output := buffer.String(); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, test.expected, output)
+		}
+	}
+}
+
+func TestLoggerClockOption(t *testing.T) {
+
+	// Expected output
+	const expected = "+0s [INFO] Test string\n+1.5s [INFO] Test string\n"
+
+	// Create a logger and then set a fake clock
+	var buffer bytes.Buffer
+	clock := mocks.NewClock(mocks.Now())
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithColorLogging(false),
+		WithTimestampEncoding(formatters.ElapsedTimestamp),
+	)
+	logger.Options(WithClock(clock.Now))
+
+	// Elapsed time should be measured using the new clock
+	logger.Info("Test string")
+	clock.Advance(1500 * time.Millisecond)
+	logger.Info("Test string")
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestLoggerTemplate(t *testing.T) {
+
+	// Expected output
+	const expected = `{"timestamp":"2006-01-02T15:04:05Z","logLevel":"INFO","template":"user {user} logged in from {ip}","message":"user alice logged in from 10.0.0.1","variables":[{"key":"user","value":"alice"},{"key":"ip","value":"10.0.0.1"}],"tags":["auth"]}` + "\n"
+
+	// Create a logger
+	var buffer bytes.Buffer
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithRecordFormatter(formatters.JSONRecord),
+		WithClock(mocks.Now),
+	)
+
+	// Values should be available to hooks as fields
+	var fields []Field
+	logger.Options(WithHook(func(log *Log) {
+		fields = append(fields, log.Fields()...)
+	}))
+
+	logger.TInfot(Tags{"auth"}, "user {user} logged in from {ip}", "alice", "10.0.0.1")
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+	if len(fields) != 2 || fields[0] != NewField("user", "alice") || fields[1] != NewField("ip", "10.0.0.1") {
+		t.Errorf("Incorrect output. Expected fields 'user' and 'ip', received '%v'", fields)
+	}
+}
+
+func TestLoggerSanitization(t *testing.T) {
+
+	// Expected output
+	const expected = "2006-01-02T15:04:05Z [\x1b[32mINFO\x1b[0m] user alice\\n2006-01-02T15:04:05Z [ERROR] forged logged in \x1b[31mred\x1b[0m\n"
+
+	// Create a logger
+	var buffer bytes.Buffer
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithClock(mocks.Now),
+		WithColorLogging(true),
+		WithSanitization(true),
+	)
+
+	logger.Info("user", "alice\n2006-01-02T15:04:05Z [ERROR] forged\x1b[2J", "logged in", Color("red", FgRed))
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expected, output)
+	}
+}
+
+type sanitizationColorTest struct {
+	colorLogging bool
+	expected     string
+}
+
+func TestLoggerSanitizationColors(t *testing.T) {
+
+	tests := []sanitizationColorTest{
+		{true, "2006-01-02T15:04:05Z [\x1b[32mINFO\x1b[0m] FORGED \x1b[31mred\x1b[0m \x1b[32mgreen\n"},
+		{false, "2006-01-02T15:04:05Z [INFO] FORGED red green\n"},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Create a logger
+		var buffer bytes.Buffer
+		logger := NewLogger(
+			WithOutput(&buffer),
+			WithClock(mocks.Now),
+			WithColorLogging(test.colorLogging),
+			WithSanitization(true),
+		)
+
+		// Only strings returned by Color() should keep their colors, and only if color logging is enabled
+		logger.Info("\x1b[41mFORGED\x1b[0m", Color("red", FgRed), Color("green", FgGreen, NoReset))
+
+		// Check if the output is correct
+		if output := buffer.String(); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", i, test.expected, output)
+		}
+	}
+}
+
+func TestLoggerMarkup(t *testing.T) {
+
+	// Expected output
+	const (
+		expectedColor = "2006-01-02T15:04:05Z [\x1b[32mINFO\x1b[0m] status \x1b[32mOK\x1b[0m \x1b[1;4mdone\x1b[0m <unknown> user=a<b>c\n2006-01-02T15:04:05Z [\x1b[32mINFO\x1b[0m] <red>\n"
+		expectedPlain = "2006-01-02T15:04:05Z [INFO] status OK done <unknown> user=a<b>c\n2006-01-02T15:04:05Z [INFO] <red>\n"
+	)
+
+	// Create a terminal logger and a file logger
+	var colorBuffer, plainBuffer bytes.Buffer
+	colorLogger := NewLogger(WithOutput(&colorBuffer), WithColorLogging(true), WithClock(mocks.Now), WithMarkup(true))
+	plainLogger := NewLogger(WithOutput(&plainBuffer), WithColorLogging(false), WithClock(mocks.Now), WithMarkup(true))
+
+	// The same call should be correct for both loggers. Markup is only read from the format string, so values are written literally
+	for _, logger := range []*Logger{colorLogger, plainLogger} {
+		logger.Infof("status <green>%s</green> {bold,underline}done{/} <unknown> user=%s", "OK", "a<b>c")
+		logger.Info("<red>")
+	}
+
+	// Check if the output is correct
+	if output := colorBuffer.String(); output != expectedColor {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expectedColor, output)
+	}
+	if output := plainBuffer.String(); output != expectedPlain {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expectedPlain, output)
+	}
+}
+
+func TestLoggerCaller(t *testing.T) {
+
+	// Create a logger that keeps the caller of each record
+	var callers []formatters.Caller
+	logger := NewLogger(
+		WithOutput(&bytes.Buffer{}),
+		WithCaller(true),
+		WithRecordFormatter(formatters.Func(func(dst []byte, record *formatters.Record, ctx *formatters.RenderContext) ([]byte, error) {
+			callers = append(callers, record.Caller)
+			return dst, nil
+		})),
+	)
+
+	// Logs written directly and by timers should report this function
+	logger.Info("Test string")
+	logger.Timer("timer").Stop()
+
+	// Check if the output is correct
+	if len(callers) != 2 {
+		t.Fatalf("Incorrect output. Expected 2 callers, received %d", len(callers))
+	}
+	for i, caller := range callers {
+		if filepath.Base(caller.File) != "logger_test.go" || caller.Function != "github.com/pd93/plog.TestLoggerCaller" || caller.Line == 0 {
+			t.Errorf("[%d] Incorrect output. Expected 'TestLoggerCaller' in 'logger_test.go', received '%v'", i, caller)
+		}
+	}
+
+	// Callers should not be recorded by default
+	logger.Options(WithCaller(false))
+	logger.Info("Test string")
+	if caller := callers[2]; caller != (formatters.Caller{}) {
+		t.Errorf("Incorrect output. Expected no caller, received '%v'", caller)
+	}
+}
+
+func TestLoggerTemplateFormatter(t *testing.T) {
+
+	// Expected output
+	const (
+		expectedColor = "\x1b[32mINFO\x1b[0m  \x1b[1;31mTest string\x1b[0m user=alice\n"
+		expectedPlain = "INFO  Test string user=alice\n"
+	)
+
+	// Create a terminal logger and a file logger with the same template
+	formatter := formatters.MustTemplate(`{{pad 5 .Level}} {{color "bold,red" .Message}}{{range .Fields}} {{.}}{{end}}`)
+	var colorBuffer, plainBuffer bytes.Buffer
+	colorLogger := NewLogger(WithOutput(&colorBuffer), WithColorLogging(true), WithRecordFormatter(formatter))
+	plainLogger := NewLogger(WithOutput(&plainBuffer), WithColorLogging(false), WithRecordFormatter(formatter))
+
+	for _, logger := range []*Logger{colorLogger, plainLogger} {
+		logger.Info("Test string", NewField("user", "alice"))
+	}
+
+	// Check if the output is correct
+	if output := colorBuffer.String(); output != expectedColor {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expectedColor, output)
+	}
+	if output := plainBuffer.String(); output != expectedPlain {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expectedPlain, output)
+	}
+}
+
+func TestLoggerErrorRecord(t *testing.T) {
+
+	// Expected output
+	const prefix = `{"@timestamp":"2006-01-02T15:04:05.000Z","log.level":"error",`
+	expected := prefix + `"message":"boom","ecs.version":"` + formatters.ECSVersion + `","error.message":"boom","error.type":"*errors.errorString"}` + "\n" +
+		prefix + `"message":"request failed: boom","ecs.version":"` + formatters.ECSVersion + `","error.message":"boom","error.type":"*errors.errorString"}` + "\n" +
+		prefix + `"message":"query","ecs.version":"` + formatters.ECSVersion + `","error.message":"boom","error.type":"*errors.errorString","duration":0}` + "\n" +
+		prefix + `"message":"login failed: ***","ecs.version":"` + formatters.ECSVersion + `","error.message":"login failed: ***","error.type":"*errors.errorString"}` + "\n"
+
+	// Create a logger
+	var buffer bytes.Buffer
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithClock(mocks.Now),
+		WithRecordFormatter(formatters.ECS),
+	)
+
+	// The error should be kept on the record, even though it is formatted into the message
+	err := errors.New("boom")
+	logger.Error(err)
+	logger.Errorf("request failed: %v", err)
+
+	// Timers add the error as a field, which should be written as the error instead of clashing with it
+	logger.Timer("query").StopWithError(err)
+
+	// Errors containing redacted values should never be written as they are
+	logger.Options(WithRedactor(NewRedactor(WithRedactPatterns(EmailPattern), WithRedactMask("***"))))
+	logger.Error(errors.New("login failed: alice@example.com"))
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestLoggerJSONErrorObjects(t *testing.T) {
+
+	// Expected output
+	const object = `{"message":"wrap: boom","type":"*fmt.wrapError","chain":[{"message":"boom","type":"*errors.errorString"}]}`
+	const expected = `{"timestamp":"2006-01-02T15:04:05Z","logLevel":"ERROR","variables":["wrap: boom"],"error":` + object + "}\n" +
+		`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"ERROR","variables":["request failed: wrap: boom"],"error":` + object + "}\n" +
+		`{"timestamp":"2006-01-02T15:04:05Z","logLevel":"WARN","variables":["request failed:",` + object + "]}\n"
+
+	// Create a logger
+	var buffer bytes.Buffer
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithClock(mocks.Now),
+		WithRecordFormatter(formatters.NewJSON(formatters.WithJSONErrorObjects(true))),
+	)
+
+	// Errors that are formatted into the message should still be written as objects, but errors in the variables are only written once
+	err := fmt.Errorf("wrap: %w", errors.New("boom"))
+	logger.Error(err)
+	logger.Errorf("request failed: %w", err)
+	logger.Warn("request failed:", err)
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+type lineDelimitedTest struct {
+	formatter RecordFormatter
+	parse     func(line string) (message string, err error) // Parses a line and returns its message
+}
+
+func TestLoggerLineDelimited(t *testing.T) {
+
+	// Expected messages
+	expected := []string{"request failed: boom", "Test string 123", "Test string"}
+
+	tests := []lineDelimitedTest{
+		{formatters.JSONRecord, func(line string) (string, error) {
+			var record struct{ Variables []string }
+			if err := json.Unmarshal([]byte(line), &record); err != nil || len(record.Variables) == 0 {
+				return "", err
+			}
+			return record.Variables[0], nil
+		}},
+		{formatters.CSVRecord, func(line string) (string, error) {
+			records, err := csv.NewReader(strings.NewReader(line)).ReadAll()
+			if err == nil && len(records) != 1 {
+				err = fmt.Errorf("expected 1 record, received %d", len(records))
+			}
+			if err != nil {
+				return "", err
+			}
+			return records[0][2], nil
+		}},
+		{formatters.Logfmt, func(line string) (string, error) {
+			fields, err := formatters.ParseLogfmt(line)
+			for _, field := range fields {
+				if field.Key == "msg" {
+					return field.Value.(string), err
+				}
+			}
+			return "", err
+		}},
+		{formatters.GCP, func(line string) (string, error) {
+			var entry struct{ Message, Severity string }
+			if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.Severity == "" {
+				return "", fmt.Errorf("expected a severity: %v", err)
+			}
+			return entry.Message, nil
+		}},
+		{formatters.NewEMF("app"), func(line string) (string, error) {
+			var entry struct{ Message, Level string }
+			if err := json.Unmarshal([]byte(line), &entry); err != nil || entry.Level == "" {
+				return "", fmt.Errorf("expected a level: %v", err)
+			}
+			return entry.Message, nil
+		}},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Create a logger
+		var buffer bytes.Buffer
+		logger := NewLogger(
+			WithOutput(&buffer),
+			WithClock(mocks.Now),
+			WithRecordFormatter(test.formatter),
+		)
+
+		// Every record should be written on its own line, even if the format doesn't end with a newline
+		logger.Errorf("request failed: %v", errors.New("boom"))
+		logger.Infof("Test string %d\n", 123)
+		logger.Info("Test string")
+
+		// Check that each line can be parsed
+		lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+		if len(lines) != 3 {
+			t.Errorf("[%d] Incorrect output. Expected 3 lines, received %d: '%q'", i, len(lines), buffer.String())
+			continue
+		}
+		for j, line := range lines {
+			message, err := test.parse(line)
+			if err != nil {
+				t.Errorf("[%d] Line %d could not be parsed: %v\n\tLine: '%s'", i, j, err, line)
+			} else if message != expected[j] {
+				t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, expected[j], message)
+			}
+		}
+	}
+}
+
+func TestLoggerEMF(t *testing.T) {
+
+	// Expected output
+	const expected = `{"_aws":{"Timestamp":1136214245000,"CloudWatchMetrics":[{"Namespace":"app","Dimensions":[["Service"]],"Metrics":[{"Name":"Latency","Unit":"Milliseconds"}]}]},"level":"INFO","message":"request","Latency":12,"Service":"api"}` + "\n"
+
+	// Create a logger
+	var buffer bytes.Buffer
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithClock(mocks.Now),
+		WithRecordFormatter(formatters.NewEMF("app", formatters.WithDimensions("Service"))),
+	)
+
+	logger.Info("request", NewMetric("Latency", 12, "Milliseconds"), NewField("Service", "api"))
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
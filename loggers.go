@@ -2,9 +2,12 @@ package plog
 
 type loggerMap map[string]*Logger
 
-// write will write a log message to all the loggers.
+// write will write a log message to all the loggers and release the log once it has been written.
 func (loggers loggerMap) write(log *Log) {
 
+	loggersMutex.RLock()
+	defer loggersMutex.RUnlock()
+
 	// Loop through each logger
 	for _, logger := range loggers {
 
@@ -12,7 +15,43 @@ func (loggers loggerMap) write(log *Log) {
 		if logger.globalLogging {
 
 			// Write to the logger
-			logger.write(log)
+			logger.emit(log)
 		}
 	}
+
+	log.release()
+}
+
+// enabled will return whether or not any of the global loggers will write logs at the given log level.
+func (loggers loggerMap) enabled(logLevel LogLevel) bool {
+
+	// Loop through each logger
+	for _, logger := range loggers {
+
+		// If the logger is global and wants logs at this level
+		if logger.globalLogging && logger.Enabled(logLevel) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// check will return whether or not any of the global loggers will write logs at the given log level.
+// Each global logger that will not write the log counts it as filtered.
+func (loggers loggerMap) check(logLevel LogLevel) (enabled bool) {
+
+	loggersMutex.RLock()
+	defer loggersMutex.RUnlock()
+
+	// Loop through each logger
+	for _, logger := range loggers {
+
+		// If the logger is global, check if it wants logs at this level
+		if logger.globalLogging && logger.check(logLevel) {
+			enabled = true
+		}
+	}
+
+	return
 }
@@ -0,0 +1,137 @@
+package plog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pd93/plog/formatters"
+)
+
+// formatMarkup will format a message in the same way as `fmt.Sprintf`, but keep the text of the format string separate from the formatted values.
+// This allows markup to be read from the format string while the values are always written literally (See `formatters.Markup`).
+func formatMarkup(format string, args []interface{}) formatters.Markup {
+
+	var message formatters.Markup
+	var text strings.Builder
+	var argNum int
+	var reordered bool
+
+	// Loop through the verbs and format each value on its own
+	start := 0
+	for i := 0; i < len(format); {
+
+		if format[i] != '%' {
+			i++
+			continue
+		}
+		text.WriteString(format[start:i])
+
+		// Copy the flags
+		j := i + 1
+		verb := []byte{'%'}
+		for ; j < len(format) && strings.IndexByte("+-# 0", format[j]) >= 0; j++ {
+			verb = append(verb, format[j])
+		}
+
+		// Copy the width and precision. Any argument indexes are written explicitly so that the verb can be formatted on its own
+		j, argNum, reordered = parseArgIndex(format, j, argNum, reordered)
+		j, verb, argNum = parseArgWidth(format, j, verb, argNum)
+		if j < len(format) && format[j] == '.' {
+			verb = append(verb, '.')
+			j, argNum, reordered = parseArgIndex(format, j+1, argNum, reordered)
+			j, verb, argNum = parseArgWidth(format, j, verb, argNum)
+		}
+		j, argNum, reordered = parseArgIndex(format, j, argNum, reordered)
+
+		// Format the value
+		var value string
+		switch r, size := utf8.DecodeRuneInString(format[j:]); {
+		case j >= len(format):
+			value = "%!(NOVERB)"
+		case r == '%':
+			text.WriteByte('%')
+			j += size
+			i, start = j, j
+			continue
+		case !reordered && argNum >= len(args):
+			value = "%!" + string(r) + "(MISSING)"
+			j += size
+		case r == 'w':
+			value = fmt.Errorf(string(appendArgIndex(verb, argNum))+"w", args...).Error()
+			argNum++
+			j += size
+		default:
+			value = fmt.Sprintf(string(appendArgIndex(verb, argNum))+string(r), args...)
+			argNum++
+			j += size
+		}
+
+		message = append(message, text.String(), value)
+		text.Reset()
+		i, start = j, j
+	}
+	text.WriteString(format[start:])
+	message = append(message, text.String())
+
+	// Any values that weren't used are listed at the end of the message
+	if !reordered && argNum < len(args) && argNum >= 0 {
+		extra := "%!(EXTRA "
+		for i, arg := range args[argNum:] {
+			if i > 0 {
+				extra += ", "
+			}
+			if arg == nil {
+				extra += "<nil>"
+			} else {
+				extra += fmt.Sprintf("%T=%v", arg, arg)
+			}
+		}
+		message = append(message, extra+")")
+	}
+
+	return message
+}
+
+// parseArgIndex will parse an argument index (e.g. "[2]") at position i of the format.
+// The position after the index and the new argument number are returned. Invalid indexes set the argument number to -1.
+func parseArgIndex(format string, i, argNum int, reordered bool) (int, int, bool) {
+
+	if i >= len(format) || format[i] != '[' {
+		return i, argNum, reordered
+	}
+
+	end := strings.IndexByte(format[i:], ']')
+	if end < 0 {
+		return len(format), -1, true
+	}
+	index, err := strconv.Atoi(format[i+1 : i+end])
+	if err != nil || index < 1 {
+		return i + end + 1, -1, true
+	}
+
+	return i + end + 1, index - 1, true
+}
+
+// parseArgWidth will copy a width or precision at position i of the format to the verb.
+// If the width is taken from an argument ("*"), the argument index is written explicitly.
+func parseArgWidth(format string, i int, verb []byte, argNum int) (int, []byte, int) {
+
+	if i < len(format) && format[i] == '*' {
+		return i + 1, append(appendArgIndex(verb, argNum), '*'), argNum + 1
+	}
+
+	for ; i < len(format) && format[i] >= '0' && format[i] <= '9'; i++ {
+		verb = append(verb, format[i])
+	}
+
+	return i, verb, argNum
+}
+
+// appendArgIndex will append an explicit argument index (e.g. "[2]") for the given argument number to the verb.
+func appendArgIndex(verb []byte, argNum int) []byte {
+	verb = append(verb, '[')
+	verb = strconv.AppendInt(verb, int64(argNum+1), 10)
+	return append(verb, ']')
+}
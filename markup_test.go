@@ -0,0 +1,40 @@
+package plog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFormatMarkup(t *testing.T) {
+
+	tests := []struct {
+		format   string
+		args     []interface{}
+		expected []string
+	}{
+		{"no verbs", nil, []string{"no verbs"}},
+		{"<red>%s</red> 100%%", []interface{}{"<b>"}, []string{"<red>", "<b>", "</red> 100%"}},
+		{"%-5d|%+.2f|%#x", []interface{}{1, 2.5, 255}, []string{"", "1    ", "|", "+2.50", "|", "0xff", ""}},
+		{"%*d %.*s", []interface{}{4, 7, 2, "abc"}, []string{"", "   7", " ", "ab", ""}},
+		{"%[2]s %[1]s", []interface{}{"a", "b"}, []string{"", "b", " ", "a", ""}},
+		{"%s %d", []interface{}{"a"}, []string{"", "a", " ", "%!d(MISSING)", ""}},
+		{"%s", []interface{}{"a", 1, nil}, []string{"", "a", "", "%!(EXTRA int=1, <nil>)"}},
+		{"%T %v %", []interface{}{1, nil}, []string{"", "int", " ", "<nil>", " ", "%!(NOVERB)", ""}},
+		{"<red>failed: %w</red>", []interface{}{errors.New("<b>")}, []string{"<red>failed: ", "<b>", "</red>"}},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		output := formatMarkup(test.format, test.args)
+
+		// Check if the output is correct and matches the message without markup
+		if strings.Join(output, "|") != strings.Join(test.expected, "|") {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", i, test.expected, []string(output))
+		}
+		if expected := sprintf(test.format, test.args...); output.String() != expected {
+			t.Errorf("[%d] Incorrect message.\n\tExpected: '%s'\n\tReceived: '%s'", i, expected, output.String())
+		}
+	}
+}
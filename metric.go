@@ -0,0 +1,21 @@
+package plog
+
+import "github.com/pd93/plog/formatters"
+
+// A Metric is a named measurement that can be passed to any logging function alongside the other variables.
+// Formatters that support metrics (e.g. `formatters.NewEMF`) publish them. Other formatters display them as 'name=value'.
+type Metric = formatters.Metric
+
+//
+// Constructors
+//
+
+// NewMetric creates and returns a metric with the given name, value and unit.
+// The unit should be one that the metric's destination understands (e.g. "Milliseconds", "Bytes" or "Count" for CloudWatch).
+func NewMetric(name string, value float64, unit string) Metric {
+	return Metric{
+		Name:  name,
+		Value: value,
+		Unit:  unit,
+	}
+}
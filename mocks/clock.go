@@ -0,0 +1,39 @@
+package mocks
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a fake clock that only moves when it is told to.
+// Pass `clock.Now` anywhere PLog accepts a clock.
+type Clock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+// NewClock creates and returns a fake clock set to the given time.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now}
+}
+
+// Now will return the clock's current time.
+func (clock *Clock) Now() time.Time {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	return clock.now
+}
+
+// Advance will move the clock forward by the given duration.
+func (clock *Clock) Advance(d time.Duration) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	clock.now = clock.now.Add(d)
+}
+
+// Set will change the clock's current time.
+func (clock *Clock) Set(now time.Time) {
+	clock.mutex.Lock()
+	defer clock.mutex.Unlock()
+	clock.now = now
+}
@@ -2,6 +2,7 @@ package plog
 
 import (
 	"fmt"
+	"sync"
 )
 
 //
@@ -11,10 +12,16 @@ import (
 // Global loggers variable.
 var loggers = make(loggerMap)
 
+// Guards the global loggers variable so that loggers can be added and removed concurrently.
+var loggersMutex sync.RWMutex
+
 // AddLogger adds the provided logger to PLog.
 // See `type Logger` for more details.
 func AddLogger(name string, logger *Logger) {
 
+	loggersMutex.Lock()
+	defer loggersMutex.Unlock()
+
 	// Check if the logger name is already used
 	if _, exists := loggers[name]; exists {
 		panic(fmt.Errorf("Logger with the name: '%s' already exists", name))
@@ -26,6 +33,9 @@ func AddLogger(name string, logger *Logger) {
 // GetLogger returns the specified logger.
 func GetLogger(name string) *Logger {
 
+	loggersMutex.RLock()
+	defer loggersMutex.RUnlock()
+
 	// Check if the logger exists
 	if val, exists := loggers[name]; !exists || val == nil {
 		panic(fmt.Errorf("Cannot return non-existent logger: '%s'", name))
@@ -37,6 +47,9 @@ func GetLogger(name string) *Logger {
 // DeleteLogger removes the specified logger from PLog.
 func DeleteLogger(name string) {
 
+	loggersMutex.Lock()
+	defer loggersMutex.Unlock()
+
 	// Check if the logger exists
 	if val, exists := loggers[name]; !exists || val == nil {
 		panic(fmt.Errorf("Cannot delete non-existent logger: '%s'", name))
@@ -49,33 +62,70 @@ func DeleteLogger(name string) {
 // Any number of functional options can be passed to this method.
 // You can read more information on functional options on the PLog wiki: https://github.com/pd93/plog/wiki/Functional-Options.
 func Options(opts ...LoggerOption) {
+
+	loggersMutex.RLock()
+	defer loggersMutex.RUnlock()
+
 	for _, logger := range loggers {
 		logger.Options(opts...)
 	}
 }
 
+// Enabled will return whether or not any of the global loggers will write logs at the given log level.
+// This can be used to avoid doing expensive work for logs that will never be written.
+func Enabled(logLevel LogLevel) bool {
+
+	loggersMutex.RLock()
+	defer loggersMutex.RUnlock()
+
+	return loggers.enabled(logLevel)
+}
+
 //
 // Fatal logging (Level 1)
 //
 
 // Fatal will print a fatal error message to all loggers.
 func Fatal(variables ...interface{}) {
-	loggers.write(newLog(FatalLevel, variables...))
+	if loggers.check(FatalLevel) {
+		loggers.write(newLog(FatalLevel, variables...))
+	}
 }
 
 // Fatalf will print a formatted, non-fatal error message.
 func Fatalf(format string, variables ...interface{}) {
-	loggers.write(newLogf(FatalLevel, format, variables...))
+	if loggers.check(FatalLevel) {
+		loggers.write(newLogf(FatalLevel, format, variables...))
+	}
 }
 
 // TFatal will print a fatal error message and meta-tag the log.
 func TFatal(tags Tags, variables ...interface{}) {
-	loggers.write(newTLog(FatalLevel, tags, variables...))
+	if loggers.check(FatalLevel) {
+		loggers.write(newTLog(FatalLevel, tags, variables...))
+	}
 }
 
 // TFatalf will print a formatted, fatal error message and meta-tag the log.
 func TFatalf(tags Tags, format string, variables ...interface{}) {
-	loggers.write(newTLogf(FatalLevel, tags, format, variables...))
+	if loggers.check(FatalLevel) {
+		loggers.write(newTLogf(FatalLevel, tags, format, variables...))
+	}
+}
+
+// Fatalt will print a fatal error message to all loggers using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func Fatalt(template string, variables ...interface{}) {
+	if loggers.check(FatalLevel) {
+		loggers.write(newLogt(FatalLevel, template, variables...))
+	}
+}
+
+// TFatalt will print a fatal error message to all loggers using a message template and meta-tag the log.
+func TFatalt(tags Tags, template string, variables ...interface{}) {
+	if loggers.check(FatalLevel) {
+		loggers.write(newTLogt(FatalLevel, tags, template, variables...))
+	}
 }
 
 //
@@ -84,22 +134,45 @@ func TFatalf(tags Tags, format string, variables ...interface{}) {
 
 // Error will print a non-fatal error message to all loggers.
 func Error(variables ...interface{}) {
-	loggers.write(newLog(ErrorLevel, variables...))
+	if loggers.check(ErrorLevel) {
+		loggers.write(newLog(ErrorLevel, variables...))
+	}
 }
 
 // Errorf will print a formatted, non-fatal error message.
 func Errorf(format string, variables ...interface{}) {
-	loggers.write(newLogf(ErrorLevel, format, variables...))
+	if loggers.check(ErrorLevel) {
+		loggers.write(newLogf(ErrorLevel, format, variables...))
+	}
 }
 
 // TError will print a non-fatal error message and meta-tag the log.
 func TError(tags Tags, variables ...interface{}) {
-	loggers.write(newTLog(ErrorLevel, tags, variables...))
+	if loggers.check(ErrorLevel) {
+		loggers.write(newTLog(ErrorLevel, tags, variables...))
+	}
 }
 
 // TErrorf will print a formatted, non-fatal error message and meta-tag the log.
 func TErrorf(tags Tags, format string, variables ...interface{}) {
-	loggers.write(newTLogf(ErrorLevel, tags, format, variables...))
+	if loggers.check(ErrorLevel) {
+		loggers.write(newTLogf(ErrorLevel, tags, format, variables...))
+	}
+}
+
+// Errort will print a non-fatal error message to all loggers using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func Errort(template string, variables ...interface{}) {
+	if loggers.check(ErrorLevel) {
+		loggers.write(newLogt(ErrorLevel, template, variables...))
+	}
+}
+
+// TErrort will print a non-fatal error message to all loggers using a message template and meta-tag the log.
+func TErrort(tags Tags, template string, variables ...interface{}) {
+	if loggers.check(ErrorLevel) {
+		loggers.write(newTLogt(ErrorLevel, tags, template, variables...))
+	}
 }
 
 //
@@ -108,22 +181,45 @@ func TErrorf(tags Tags, format string, variables ...interface{}) {
 
 // Warn will print any number of variables to all loggers at warn level.
 func Warn(variables ...interface{}) {
-	loggers.write(newLog(WarnLevel, variables...))
+	if loggers.check(WarnLevel) {
+		loggers.write(newLog(WarnLevel, variables...))
+	}
 }
 
 // Warnf will print a formatted message to all loggers at warn level.
 func Warnf(format string, variables ...interface{}) {
-	loggers.write(newLogf(WarnLevel, format, variables...))
+	if loggers.check(WarnLevel) {
+		loggers.write(newLogf(WarnLevel, format, variables...))
+	}
 }
 
 // TWarn will print any number of variables at warn level and meta-tag the log.
 func TWarn(tags Tags, variables ...interface{}) {
-	loggers.write(newTLog(WarnLevel, tags, variables...))
+	if loggers.check(WarnLevel) {
+		loggers.write(newTLog(WarnLevel, tags, variables...))
+	}
 }
 
 // TWarnf will print a formatted message at warn level and meta-tag the log.
 func TWarnf(tags Tags, format string, variables ...interface{}) {
-	loggers.write(newTLogf(WarnLevel, tags, format, variables...))
+	if loggers.check(WarnLevel) {
+		loggers.write(newTLogf(WarnLevel, tags, format, variables...))
+	}
+}
+
+// Warnt will print a warning to all loggers using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func Warnt(template string, variables ...interface{}) {
+	if loggers.check(WarnLevel) {
+		loggers.write(newLogt(WarnLevel, template, variables...))
+	}
+}
+
+// TWarnt will print a warning to all loggers using a message template and meta-tag the log.
+func TWarnt(tags Tags, template string, variables ...interface{}) {
+	if loggers.check(WarnLevel) {
+		loggers.write(newTLogt(WarnLevel, tags, template, variables...))
+	}
 }
 
 //
@@ -132,22 +228,45 @@ func TWarnf(tags Tags, format string, variables ...interface{}) {
 
 // Info will print any number of variables to all loggers at info level.
 func Info(variables ...interface{}) {
-	loggers.write(newLog(InfoLevel, variables...))
+	if loggers.check(InfoLevel) {
+		loggers.write(newLog(InfoLevel, variables...))
+	}
 }
 
 // Infof will print a formatted message to all loggers at info level.
 func Infof(format string, variables ...interface{}) {
-	loggers.write(newLogf(InfoLevel, format, variables...))
+	if loggers.check(InfoLevel) {
+		loggers.write(newLogf(InfoLevel, format, variables...))
+	}
 }
 
 // TInfo will print any number of variables at info level and meta-tag the log.
 func TInfo(tags Tags, variables ...interface{}) {
-	loggers.write(newTLog(InfoLevel, tags, variables...))
+	if loggers.check(InfoLevel) {
+		loggers.write(newTLog(InfoLevel, tags, variables...))
+	}
 }
 
 // TInfof will print a formatted message at info level and meta-tag the log.
 func TInfof(tags Tags, format string, variables ...interface{}) {
-	loggers.write(newTLogf(InfoLevel, tags, format, variables...))
+	if loggers.check(InfoLevel) {
+		loggers.write(newTLogf(InfoLevel, tags, format, variables...))
+	}
+}
+
+// Infot will print a message at info level to all loggers using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func Infot(template string, variables ...interface{}) {
+	if loggers.check(InfoLevel) {
+		loggers.write(newLogt(InfoLevel, template, variables...))
+	}
+}
+
+// TInfot will print a message at info level to all loggers using a message template and meta-tag the log.
+func TInfot(tags Tags, template string, variables ...interface{}) {
+	if loggers.check(InfoLevel) {
+		loggers.write(newTLogt(InfoLevel, tags, template, variables...))
+	}
 }
 
 //
@@ -156,22 +275,45 @@ func TInfof(tags Tags, format string, variables ...interface{}) {
 
 // Debug will print any number of variables to all loggers at debug level.
 func Debug(variables ...interface{}) {
-	loggers.write(newLog(DebugLevel, variables...))
+	if loggers.check(DebugLevel) {
+		loggers.write(newLog(DebugLevel, variables...))
+	}
 }
 
 // Debugf will print a formatted message to all loggers at debug level.
 func Debugf(format string, variables ...interface{}) {
-	loggers.write(newLogf(DebugLevel, format, variables...))
+	if loggers.check(DebugLevel) {
+		loggers.write(newLogf(DebugLevel, format, variables...))
+	}
 }
 
 // TDebug will print any number of variables at debug level and meta-tag the log.
 func TDebug(tags Tags, variables ...interface{}) {
-	loggers.write(newTLog(DebugLevel, tags, variables...))
+	if loggers.check(DebugLevel) {
+		loggers.write(newTLog(DebugLevel, tags, variables...))
+	}
 }
 
 // TDebugf will print a formatted message at debug level and meta-tag the log.
 func TDebugf(tags Tags, format string, variables ...interface{}) {
-	loggers.write(newTLogf(DebugLevel, tags, format, variables...))
+	if loggers.check(DebugLevel) {
+		loggers.write(newTLogf(DebugLevel, tags, format, variables...))
+	}
+}
+
+// Debugt will print a message at debug level to all loggers using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func Debugt(template string, variables ...interface{}) {
+	if loggers.check(DebugLevel) {
+		loggers.write(newLogt(DebugLevel, template, variables...))
+	}
+}
+
+// TDebugt will print a message at debug level to all loggers using a message template and meta-tag the log.
+func TDebugt(tags Tags, template string, variables ...interface{}) {
+	if loggers.check(DebugLevel) {
+		loggers.write(newTLogt(DebugLevel, tags, template, variables...))
+	}
 }
 
 //
@@ -180,20 +322,43 @@ func TDebugf(tags Tags, format string, variables ...interface{}) {
 
 // Trace will print any number of variables to all loggers at debug level.
 func Trace(variables ...interface{}) {
-	loggers.write(newLog(TraceLevel, variables...))
+	if loggers.check(TraceLevel) {
+		loggers.write(newLog(TraceLevel, variables...))
+	}
 }
 
 // Tracef will print a formatted message to all loggers at debug level.
 func Tracef(format string, variables ...interface{}) {
-	loggers.write(newLogf(TraceLevel, format, variables...))
+	if loggers.check(TraceLevel) {
+		loggers.write(newLogf(TraceLevel, format, variables...))
+	}
 }
 
 // TTrace will print any number of variables at trace level and meta-tag the log.
 func TTrace(tags Tags, variables ...interface{}) {
-	loggers.write(newTLog(TraceLevel, tags, variables...))
+	if loggers.check(TraceLevel) {
+		loggers.write(newTLog(TraceLevel, tags, variables...))
+	}
 }
 
 // TTracef will print a formatted message at trace level and meta-tag the log.
 func TTracef(tags Tags, format string, variables ...interface{}) {
-	loggers.write(newTLogf(TraceLevel, tags, format, variables...))
+	if loggers.check(TraceLevel) {
+		loggers.write(newTLogf(TraceLevel, tags, format, variables...))
+	}
+}
+
+// Tracet will print a message at trace level to all loggers using a message template.
+// Each placeholder (e.g. "{user}") is replaced by the next variable and the values are kept as fields.
+func Tracet(template string, variables ...interface{}) {
+	if loggers.check(TraceLevel) {
+		loggers.write(newLogt(TraceLevel, template, variables...))
+	}
+}
+
+// TTracet will print a message at trace level to all loggers using a message template and meta-tag the log.
+func TTracet(tags Tags, template string, variables ...interface{}) {
+	if loggers.check(TraceLevel) {
+		loggers.write(newTLogt(TraceLevel, tags, template, variables...))
+	}
 }
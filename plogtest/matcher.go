@@ -0,0 +1,59 @@
+package plogtest
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pd93/plog"
+)
+
+// A Matcher is a function that reports whether or not a record meets some condition.
+type Matcher func(record Record) bool
+
+// Level will return a matcher that checks the log level of a record.
+func Level(logLevel plog.LogLevel) Matcher {
+	return func(record Record) bool {
+		return record.LogLevel == logLevel
+	}
+}
+
+// Contains will return a matcher that checks if a record's message contains the given substring.
+func Contains(substring string) Matcher {
+	return func(record Record) bool {
+		return strings.Contains(record.Message, substring)
+	}
+}
+
+// HasTag will return a matcher that checks if a record was tagged with the given tag.
+func HasTag(tag plog.Tag) Matcher {
+	return func(record Record) bool {
+		return record.HasTag(tag)
+	}
+}
+
+// HasField will return a matcher that checks if a record contains a field with the given key and value.
+// Values are compared using reflect.DeepEqual.
+func HasField(key string, value interface{}) Matcher {
+	return func(record Record) bool {
+		v, ok := record.Field(key)
+		return ok && reflect.DeepEqual(v, value)
+	}
+}
+
+// HasFieldKey will return a matcher that checks if a record contains a field with the given key.
+func HasFieldKey(key string) Matcher {
+	return func(record Record) bool {
+		_, ok := record.Field(key)
+		return ok
+	}
+}
+
+// matchAll will return whether or not the record satisfies every one of the given matchers.
+func matchAll(record Record, matchers []Matcher) bool {
+	for _, matcher := range matchers {
+		if !matcher(record) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,80 @@
+package plogtest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pd93/plog"
+	"github.com/pd93/plog/formatters"
+)
+
+// A Record is a copy of a single log that was written to a recorder.
+type Record struct {
+	LogLevel  plog.LogLevel
+	Timestamp time.Time
+	Variables []interface{}
+	Tags      plog.Tags
+	Fields    []plog.Field
+	Template  string
+	Message   string
+}
+
+// newRecord creates a new record from the given log.
+// The variables and tags are copied so that the record is not affected if the caller reuses them.
+func newRecord(log *plog.Log) Record {
+
+	variables := append([]interface{}(nil), log.Variables()...)
+	strVariables := make([]string, len(variables))
+
+	// Loop through the variables and format them
+	for i, variable := range variables {
+		strVariables[i] = fmt.Sprintf("%v", variable)
+	}
+
+	// If the log has a template, render it as the message
+	message := strings.Join(strVariables, " ")
+	if log.Template() != "" {
+		message = (&formatters.Record{Template: log.Template(), Variables: variables}).Message()
+	}
+
+	return Record{
+		LogLevel:  log.LogLevel(),
+		Timestamp: log.Timestamp(),
+		Variables: variables,
+		Tags:      append(plog.Tags(nil), log.Tags()...),
+		Fields:    log.Fields(),
+		Template:  log.Template(),
+		Message:   message,
+	}
+}
+
+//
+// Getters
+//
+
+// HasTag will return whether or not the record was tagged with the given tag.
+func (record Record) HasTag(tag plog.Tag) bool {
+	for _, t := range record.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Field will return the value of the field with the given key and whether or not it was found.
+// If the record contains the key more than once, the first value is returned.
+func (record Record) Field(key string) (interface{}, bool) {
+	for _, field := range record.Fields {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// String will stringify the record into a readable format for test failure messages.
+func (record Record) String() string {
+	return fmt.Sprintf("[%s] %v %s", record.LogLevel.String(false, nil), record.Tags, record.Message)
+}
@@ -0,0 +1,269 @@
+// Package plogtest provides a recording logger for testing code that logs using PLog.
+package plogtest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pd93/plog"
+	"github.com/pd93/plog/formatters"
+)
+
+//
+// Structures
+//
+
+// A Recorder is a logger that stores a copy of every log written to it.
+// By default, calls to the global logging functions (e.g. `plog.Info()`) are recorded as well as logs written directly to `recorder.Logger()`.
+// Use `WithGlobalLogging(false)` to only record logs written directly to the recorder's logger.
+type Recorder struct {
+	t             testing.TB
+	name          string
+	logger        *plog.Logger
+	logLevel      plog.LogLevel
+	globalLogging bool
+	testLogging   bool
+	mutex         sync.Mutex
+	records       []Record
+}
+
+// A RecorderOption is a function that sets an option on a given recorder.
+type RecorderOption func(recorder *Recorder)
+
+// Used to give each recorder a unique default name.
+var recorderCount uint64
+
+//
+// Constructors
+//
+
+// NewRecorder creates and returns a recorder for the given test.
+// Any number of functional options can be passed to this method and they will be applied on creation.
+func NewRecorder(t testing.TB, opts ...RecorderOption) (recorder *Recorder) {
+
+	// Create a default recorder
+	recorder = &Recorder{
+		t:             t,
+		name:          fmt.Sprintf("plogtest/%s/%d", t.Name(), atomic.AddUint64(&recorderCount, 1)),
+		logLevel:      plog.TraceLevel,
+		globalLogging: true,
+		testLogging:   false,
+	}
+
+	// Apply the custom options
+	for _, opt := range opts {
+		opt(recorder)
+	}
+
+	// Records are always stored, but are only forwarded to the test log if requested
+	output := ioutil.Discard
+	if recorder.testLogging {
+		output = &testWriter{t}
+	}
+
+	recorder.logger = plog.NewLogger(
+		plog.WithOutput(output),
+		plog.WithLogLevel(recorder.logLevel),
+		plog.WithRecordFormatter(formatters.TextRecord),
+		plog.WithColorLogging(false),
+		plog.WithGlobalLogging(recorder.globalLogging),
+		plog.WithHook(recorder.record),
+	)
+
+	// If the recorder captures global logs, register it and remove it again once the test is complete
+	if recorder.globalLogging {
+		plog.AddLogger(recorder.name, recorder.logger)
+		t.Cleanup(func() {
+			plog.DeleteLogger(recorder.name)
+		})
+	}
+
+	return
+}
+
+//
+// Functional Options
+//
+
+// WithName will return a function that sets the name the recorder is registered under.
+func WithName(name string) RecorderOption {
+	return func(recorder *Recorder) {
+		recorder.name = name
+	}
+}
+
+// WithLogLevel will return a function that sets the log level of the recorder.
+// The default log level is TraceLevel (record everything).
+func WithLogLevel(logLevel plog.LogLevel) RecorderOption {
+	return func(recorder *Recorder) {
+		recorder.logLevel = logLevel
+	}
+}
+
+// WithGlobalLogging will return a function that controls whether or not the recorder captures the global logging functions.
+// Global logging is enabled by default. The global loggers are shared by every test in the package, so a global recorder will also capture
+// the global logs of any tests running in parallel. Tests that call `t.Parallel()` should disable global logging and pass `recorder.Logger()` to the code under test.
+func WithGlobalLogging(globalLogging bool) RecorderOption {
+	return func(recorder *Recorder) {
+		recorder.globalLogging = globalLogging
+	}
+}
+
+// WithTestLogging will return a function that controls whether or not records are forwarded to `t.Log()`.
+// The testing package only prints these messages when a test fails or when running with the '-v' flag.
+func WithTestLogging(testLogging bool) RecorderOption {
+	return func(recorder *Recorder) {
+		recorder.testLogging = testLogging
+	}
+}
+
+//
+// Getters
+//
+
+// Name will return the name the recorder is registered under.
+func (recorder *Recorder) Name() string {
+	return recorder.name
+}
+
+// Logger will return the recorder's logger so that it can be passed to the code under test.
+func (recorder *Recorder) Logger() *plog.Logger {
+	return recorder.logger
+}
+
+// Records will return a copy of every record written so far.
+func (recorder *Recorder) Records() []Record {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return append([]Record(nil), recorder.records...)
+}
+
+// Find will return every record that satisfies all of the given matchers.
+func (recorder *Recorder) Find(matchers ...Matcher) (records []Record) {
+	for _, record := range recorder.Records() {
+		if matchAll(record, matchers) {
+			records = append(records, record)
+		}
+	}
+	return
+}
+
+//
+// Instance methods
+//
+
+// Reset will delete all the records written so far.
+func (recorder *Recorder) Reset() {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.records = nil
+}
+
+// record is used as a hook on the recorder's logger.
+func (recorder *Recorder) record(log *plog.Log) {
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	recorder.records = append(recorder.records, newRecord(log))
+}
+
+//
+// Assertions
+//
+
+// AssertLogged will fail the test if no record was written at the given log level containing the given substring.
+func (recorder *Recorder) AssertLogged(t testing.TB, logLevel plog.LogLevel, substring string) {
+	t.Helper()
+
+	if len(recorder.Find(Level(logLevel), Contains(substring))) == 0 {
+		t.Errorf("Expected a %s log containing '%s'.\n%s", logLevel.String(false, nil), substring, recorder.dump())
+	}
+}
+
+// AssertNotLogged will fail the test if any record was written at the given log level containing the given substring.
+func (recorder *Recorder) AssertNotLogged(t testing.TB, logLevel plog.LogLevel, substring string) {
+	t.Helper()
+
+	if len(recorder.Find(Level(logLevel), Contains(substring))) > 0 {
+		t.Errorf("Unexpected %s log containing '%s'.\n%s", logLevel.String(false, nil), substring, recorder.dump())
+	}
+}
+
+// AssertMatch will fail the test if no record satisfies all of the given matchers.
+func (recorder *Recorder) AssertMatch(t testing.TB, matchers ...Matcher) {
+	t.Helper()
+
+	if len(recorder.Find(matchers...)) == 0 {
+		t.Errorf("Expected a log matching the given conditions.\n%s", recorder.dump())
+	}
+}
+
+// AssertNoMatch will fail the test if any record satisfies all of the given matchers.
+func (recorder *Recorder) AssertNoMatch(t testing.TB, matchers ...Matcher) {
+	t.Helper()
+
+	if len(recorder.Find(matchers...)) > 0 {
+		t.Errorf("Unexpected log matching the given conditions.\n%s", recorder.dump())
+	}
+}
+
+// AssertNoErrors will fail the test if any record was written at error or fatal level.
+func (recorder *Recorder) AssertNoErrors(t testing.TB) {
+	t.Helper()
+
+	for _, record := range recorder.Records() {
+		if record.LogLevel == plog.ErrorLevel || record.LogLevel == plog.FatalLevel {
+			t.Errorf("Unexpected error log: %s", record)
+		}
+	}
+}
+
+// AssertCount will fail the test if the number of records satisfying all of the given matchers is not equal to n.
+func (recorder *Recorder) AssertCount(t testing.TB, n int, matchers ...Matcher) {
+	t.Helper()
+
+	if count := len(recorder.Find(matchers...)); count != n {
+		t.Errorf("Expected %d matching logs, found %d.\n%s", n, count, recorder.dump())
+	}
+}
+
+// dump will list every record for use in failure messages.
+func (recorder *Recorder) dump() string {
+
+	records := recorder.Records()
+	if len(records) == 0 {
+		return "No logs were recorded."
+	}
+
+	lines := make([]string, len(records))
+	for i, record := range records {
+		lines[i] = fmt.Sprintf("\t%s", record)
+	}
+
+	return fmt.Sprintf("Recorded logs:\n%s", strings.Join(lines, "\n"))
+}
+
+//
+// Test writer
+//
+
+// testWriter forwards anything written to it to the test log.
+type testWriter struct {
+	t testing.TB
+}
+
+// Write will log the given bytes to the test log without a trailing new line.
+func (writer *testWriter) Write(p []byte) (int, error) {
+	writer.t.Helper()
+	writer.t.Log(strings.TrimRight(string(p), "\n"))
+	return len(p), nil
+}
@@ -0,0 +1,80 @@
+package plogtest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pd93/plog"
+)
+
+// fakeT captures failures so that we can test the assertions themselves.
+type fakeT struct {
+	testing.TB
+	failures []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func TestRecorder(t *testing.T) {
+
+	recorder := NewRecorder(t, WithTestLogging(true))
+
+	// Write to the global loggers and directly to the recorder's logger
+	plog.TInfo(plog.Tags{"tag1"}, "Test string", plog.NewField("user", "alice"))
+	recorder.Logger().Error(errors.New("Test error"))
+
+	records := recorder.Records()
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, received %d", len(records))
+	}
+
+	// Check if the records are correct
+	if records[0].LogLevel != plog.InfoLevel || records[0].Message != "Test string user=alice" {
+		t.Errorf("Incorrect record: %s", records[0])
+	}
+	if records[1].LogLevel != plog.ErrorLevel || records[1].Message != "Test error" {
+		t.Errorf("Incorrect record: %s", records[1])
+	}
+
+	recorder.AssertLogged(t, plog.InfoLevel, "Test string")
+	recorder.AssertMatch(t, HasTag("tag1"), HasField("user", "alice"))
+	recorder.AssertNoMatch(t, HasTag("tag2"))
+	recorder.AssertCount(t, 1, Level(plog.ErrorLevel))
+
+	// Resetting the recorder should remove the error log
+	recorder.Reset()
+	recorder.AssertNoErrors(t)
+}
+
+func TestRecorderAssertions(t *testing.T) {
+
+	recorder := NewRecorder(t, WithGlobalLogging(false))
+	recorder.Logger().Warn("Test string")
+
+	// Global logs should not be recorded if global logging is disabled
+	plog.Error("Global error")
+
+	ft := &fakeT{TB: t}
+	recorder.AssertLogged(ft, plog.InfoLevel, "Test string")
+	recorder.AssertNotLogged(ft, plog.WarnLevel, "Test")
+	recorder.AssertMatch(ft, HasFieldKey("user"))
+	recorder.AssertNoErrors(ft)
+
+	// Only the first three assertions should fail
+	if len(ft.failures) != 3 {
+		t.Errorf("Expected 3 failures, received %d: %v", len(ft.failures), ft.failures)
+	}
+}
+
+func TestRecorderLogLevel(t *testing.T) {
+
+	recorder := NewRecorder(t, WithLogLevel(plog.InfoLevel))
+	recorder.Logger().Debug("Test string")
+
+	recorder.AssertCount(t, 0)
+}
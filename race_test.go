@@ -0,0 +1,7 @@
+//go:build race
+// +build race
+
+package plog
+
+// The race detector causes sync.Pool to drop items at random, so allocations cannot be counted.
+const raceEnabled = true
@@ -0,0 +1,348 @@
+package plog
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// The maximum depth that the redactor will search nested values.
+// This protects against cycles in self-referencing structures.
+const maxRedactDepth = 16
+
+// Common patterns for sensitive information:
+var (
+	// CreditCardPattern matches credit card numbers, with or without spaces or dashes between the digits
+	CreditCardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+	// BearerTokenPattern matches bearer tokens in authorization headers
+	BearerTokenPattern = regexp.MustCompile(`(?i)\bbearer\s+[a-z0-9\-._~+/]+=*`)
+	// EmailPattern matches email addresses
+	EmailPattern = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+)
+
+//
+// Structures
+//
+
+// A Redactor masks sensitive information before it is formatted.
+// Values are redacted if:
+//   - A string (or part of a string) matches one of the redactor's patterns
+//   - A field, map key or struct field name matches one of the redactor's keys (case insensitive)
+//   - A struct field is tagged with `plog:"redact"` (masked) or `plog:"-"` (set to its zero value)
+//
+// Values are never modified in place. Instead, a redacted copy is made.
+type Redactor struct {
+	patterns []*regexp.Regexp
+	keys     map[string]bool
+	mask     string
+}
+
+// A RedactorOption is a function that sets an option on a given redactor.
+type RedactorOption func(redactor *Redactor)
+
+//
+// Constructors
+//
+
+// NewRedactor creates and returns an instance of Redactor with the default values.
+// By default, the redactor has no rules and uses the mask '[REDACTED]'.
+// Any number of functional options can be passed to this method and they will be applied on creation.
+// You can read more information on functional options on the PLog wiki: https://github.com/pd93/plog/wiki/Functional-Options.
+func NewRedactor(opts ...RedactorOption) (redactor *Redactor) {
+
+	// Create a default redactor
+	redactor = &Redactor{
+		keys: make(map[string]bool),
+		mask: "[REDACTED]",
+	}
+
+	// Apply the custom options
+	redactor.Options(opts...)
+
+	return
+}
+
+//
+// Functional Options
+//
+
+// WithRedactPatterns will return a function that adds regular expressions to a redactor.
+// Any part of a string that matches one of the patterns is replaced by the mask.
+// PLog includes several patterns for convenience (e.g. `CreditCardPattern`).
+func WithRedactPatterns(patterns ...*regexp.Regexp) RedactorOption {
+	return func(redactor *Redactor) {
+		redactor.patterns = append(redactor.patterns, patterns...)
+	}
+}
+
+// WithRedactKeys will return a function that adds key names to a redactor.
+// Any field, map entry or struct field with one of these names is masked. Names are not case sensitive.
+func WithRedactKeys(keys ...string) RedactorOption {
+	return func(redactor *Redactor) {
+		for _, key := range keys {
+			redactor.keys[strings.ToLower(key)] = true
+		}
+	}
+}
+
+// WithRedactMask will return a function that sets the string used to replace redacted values.
+func WithRedactMask(mask string) RedactorOption {
+	return func(redactor *Redactor) {
+		redactor.mask = mask
+	}
+}
+
+//
+// Options Setter
+//
+
+// Options will apply the given options to the redactor.
+// Any number of functional options can be passed to this method.
+// You can read more information on functional options on the PLog wiki: https://github.com/pd93/plog/wiki/Functional-Options.
+func (redactor *Redactor) Options(opts ...RedactorOption) {
+	for _, opt := range opts {
+		opt(redactor)
+	}
+}
+
+//
+// Getters
+//
+
+// Mask will return the string used to replace redacted values.
+func (redactor *Redactor) Mask() string {
+	return redactor.mask
+}
+
+//
+// Instance methods
+//
+
+// Redact will return a redacted copy of the given value.
+// If nothing needs to be redacted, the original value is returned.
+func (redactor *Redactor) Redact(value interface{}) interface{} {
+
+	// Handle common types without reflection
+	switch v := value.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return value
+	case string:
+		return redactor.RedactString(v)
+	case Field:
+		if redactor.isKey(v.Key) {
+			v.Value = redactor.mask
+		} else {
+			v.Value = redactor.Redact(v.Value)
+		}
+		return v
+	case error:
+		if s := v.Error(); redactor.RedactString(s) != s {
+			return redactor.RedactString(s)
+		}
+		return value
+	}
+
+	if redacted, changed := redactor.redactValue(reflect.ValueOf(value), 0); changed {
+		return redacted.Interface()
+	}
+
+	return value
+}
+
+// RedactString will replace any part of the string that matches one of the redactor's patterns.
+func (redactor *Redactor) RedactString(s string) string {
+	for _, pattern := range redactor.patterns {
+		s = pattern.ReplaceAllLiteralString(s, redactor.mask)
+	}
+	return s
+}
+
+// isKey will return whether or not the given name is one of the redactor's keys.
+func (redactor *Redactor) isKey(name string) bool {
+	return len(redactor.keys) > 0 && redactor.keys[strings.ToLower(name)]
+}
+
+// masked will return a value of the given type that replaces a redacted value.
+// Strings are set to the mask and any other type is set to its zero value.
+func (redactor *Redactor) masked(t reflect.Type) reflect.Value {
+
+	mask := reflect.ValueOf(redactor.mask)
+
+	switch {
+	case t.Kind() == reflect.String:
+		return mask.Convert(t)
+	case t.Kind() == reflect.Interface && mask.Type().Implements(t):
+		value := reflect.New(t).Elem()
+		value.Set(mask)
+		return value
+	default:
+		return reflect.Zero(t)
+	}
+}
+
+// redactValue will return a redacted copy of the given value and whether or not anything was redacted.
+// The copy has the same type as the original value.
+func (redactor *Redactor) redactValue(v reflect.Value, depth int) (reflect.Value, bool) {
+
+	if !v.IsValid() || depth > maxRedactDepth {
+		return v, false
+	}
+
+	switch v.Kind() {
+
+	case reflect.String:
+		s := redactor.RedactString(v.String())
+		if s == v.String() {
+			return v, false
+		}
+		return reflect.ValueOf(s).Convert(v.Type()), true
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, false
+		}
+		elem, changed := redactor.redactValue(v.Elem(), depth+1)
+		if !changed {
+			return v, false
+		}
+		value := reflect.New(v.Type()).Elem()
+		value.Set(elem)
+		return value, true
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v, false
+		}
+		elem, changed := redactor.redactValue(v.Elem(), depth+1)
+		if !changed {
+			return v, false
+		}
+		value := reflect.New(elem.Type())
+		value.Elem().Set(elem)
+		return value, true
+
+	case reflect.Struct:
+		return redactor.redactStruct(v, depth)
+
+	case reflect.Map:
+		return redactor.redactMap(v, depth)
+
+	case reflect.Slice, reflect.Array:
+		return redactor.redactSlice(v, depth)
+	}
+
+	return v, false
+}
+
+// redactStruct will return a redacted copy of the given struct.
+func (redactor *Redactor) redactStruct(v reflect.Value, depth int) (reflect.Value, bool) {
+
+	var changed bool
+
+	// Copy the struct so that unexported fields are kept
+	value := reflect.New(v.Type()).Elem()
+	value.Set(v)
+
+	// Loop through the exported fields
+	for i := 0; i < v.NumField(); i++ {
+
+		structField := v.Type().Field(i)
+		if structField.PkgPath != "" {
+			continue
+		}
+
+		switch tag := structField.Tag.Get("plog"); {
+
+		// Remove the value entirely
+		case tag == "-":
+			value.Field(i).Set(reflect.Zero(structField.Type))
+			changed = true
+
+		// Mask the value
+		case tag == "redact" || redactor.isKey(structField.Name):
+			value.Field(i).Set(redactor.masked(structField.Type))
+			changed = true
+
+		// Search the value
+		default:
+			if field, ok := redactor.redactValue(v.Field(i), depth+1); ok {
+				value.Field(i).Set(field)
+				changed = true
+			}
+		}
+	}
+
+	return value, changed
+}
+
+// redactMap will return a redacted copy of the given map.
+func (redactor *Redactor) redactMap(v reflect.Value, depth int) (reflect.Value, bool) {
+
+	if v.IsNil() {
+		return v, false
+	}
+
+	var changed bool
+	value := reflect.MakeMapWithSize(v.Type(), v.Len())
+
+	// Loop through the entries
+	for _, key := range v.MapKeys() {
+
+		elem := v.MapIndex(key)
+
+		// Mask the value if the key matches, otherwise search the value
+		if key.Kind() == reflect.String && redactor.isKey(key.String()) {
+			elem = redactor.masked(v.Type().Elem())
+			changed = true
+		} else if redacted, ok := redactor.redactValue(elem, depth+1); ok {
+			elem = redacted
+			changed = true
+		}
+
+		value.SetMapIndex(key, elem)
+	}
+
+	if !changed {
+		return v, false
+	}
+
+	return value, true
+}
+
+// redactSlice will return a redacted copy of the given slice or array.
+func (redactor *Redactor) redactSlice(v reflect.Value, depth int) (reflect.Value, bool) {
+
+	// Byte slices cannot contain anything to redact
+	if v.Type().Elem().Kind() == reflect.Uint8 {
+		return v, false
+	}
+
+	var value reflect.Value
+
+	// Loop through the elements
+	for i := 0; i < v.Len(); i++ {
+
+		elem, ok := redactor.redactValue(v.Index(i), depth+1)
+		if !ok {
+			continue
+		}
+
+		// Only copy the slice once something needs to be redacted
+		if !value.IsValid() {
+			if v.Kind() == reflect.Array {
+				value = reflect.New(v.Type()).Elem()
+			} else {
+				value = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			}
+			reflect.Copy(value, v)
+		}
+
+		value.Index(i).Set(elem)
+	}
+
+	if !value.IsValid() {
+		return v, false
+	}
+
+	return value, true
+}
@@ -0,0 +1,199 @@
+package plog
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/pd93/plog/formatters"
+	"github.com/pd93/plog/mocks"
+)
+
+type redactorTest struct {
+	input    interface{}
+	expected interface{}
+}
+
+type redactorUser struct {
+	Name     string
+	Email    string
+	Password string
+	PIN      int    `plog:"redact"`
+	Internal string `plog:"-"`
+	Address  *redactorAddress
+	Friends  []redactorUser
+	age      int
+}
+
+type redactorAddress struct {
+	Street string
+	Notes  map[string]interface{}
+}
+
+func TestRedactor(t *testing.T) {
+
+	redactor := NewRedactor(
+		WithRedactPatterns(CreditCardPattern, BearerTokenPattern, EmailPattern),
+		WithRedactKeys("password", "Token"),
+	)
+
+	tests := []redactorTest{
+		{123, 123},
+		{"nothing to hide", "nothing to hide"},
+		{"card 4111 1111 1111 1111 used", "card [REDACTED] used"},
+		{"Authorization: Bearer abc.def-123", "Authorization: [REDACTED]"},
+		{"contact alice@example.com", "contact [REDACTED]"},
+		{fmt.Errorf("bad email bob@example.com"), "bad email [REDACTED]"},
+		{NewField("token", "abc"), NewField("token", "[REDACTED]")},
+		{NewField("user", "bob@example.com"), NewField("user", "[REDACTED]")},
+		{
+			map[string]interface{}{"password": "hunter2", "TOKEN": 123, "name": "alice"},
+			map[string]interface{}{"password": "[REDACTED]", "TOKEN": "[REDACTED]", "name": "alice"},
+		},
+		{map[string]int{"password": 1234}, map[string]int{"password": 0}},
+		{[]string{"a", "alice@example.com"}, []string{"a", "[REDACTED]"}},
+		{[2]string{"a", "alice@example.com"}, [2]string{"a", "[REDACTED]"}},
+		{
+			redactorUser{
+				Name:     "alice",
+				Email:    "alice@example.com",
+				Password: "hunter2",
+				PIN:      1234,
+				Internal: "secret",
+				Address: &redactorAddress{
+					Street: "1 Main St",
+					Notes:  map[string]interface{}{"password": "hunter2"},
+				},
+				Friends: []redactorUser{{Name: "bob", Email: "bob@example.com"}},
+				age:     30,
+			},
+			redactorUser{
+				Name:     "alice",
+				Email:    "[REDACTED]",
+				Password: "[REDACTED]",
+				Address: &redactorAddress{
+					Street: "1 Main St",
+					Notes:  map[string]interface{}{"password": "[REDACTED]"},
+				},
+				Friends: []redactorUser{{Name: "bob", Email: "[REDACTED]", Password: "[REDACTED]"}},
+				age:     30,
+			},
+		},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+		if output := redactor.Redact(test.input); !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("[%d] Incorrect output. Expected '%#v', received '%#v'", i, test.expected, output)
+		}
+	}
+}
+
+func TestRedactorCopy(t *testing.T) {
+
+	redactor := NewRedactor(WithRedactKeys("password"))
+
+	// Redacting should not modify the original value
+	user := &redactorUser{Name: "alice", Password: "hunter2"}
+	notes := map[string]interface{}{"password": "hunter2"}
+	redactor.Redact(user)
+	redactor.Redact(notes)
+
+	if user.Password != "hunter2" {
+		t.Errorf("Incorrect output. Expected 'hunter2', received '%s'", user.Password)
+	}
+	if notes["password"] != "hunter2" {
+		t.Errorf("Incorrect output. Expected 'hunter2', received '%s'", notes["password"])
+	}
+}
+
+func TestLoggerRedactor(t *testing.T) {
+
+	// Expected output
+	const expected = `{"timestamp":"2006-01-02T15:04:05Z","logLevel":"INFO","variables":["card ***",{"key":"password","value":"***"},{"Name":"alice","Password":"***"}],"tags":["***"]}` + "\n"
+
+	type user struct {
+		Name     string
+		Password string
+	}
+
+	// Create a logger
+	var buffer bytes.Buffer
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithRecordFormatter(formatters.JSONRecord),
+		WithClock(mocks.Now),
+		WithRedactor(NewRedactor(
+			WithRedactPatterns(CreditCardPattern, EmailPattern),
+			WithRedactKeys("password"),
+			WithRedactMask("***"),
+		)),
+	)
+
+	// Hooks should receive the redacted values
+	var hooked []interface{}
+	logger.Options(WithHook(func(log *Log) {
+		hooked = append(hooked, log.Variables()...)
+	}))
+
+	logger.TInfo(Tags{"alice@example.com"}, "card 4111-1111-1111-1111", NewField("password", "hunter2"), user{"alice", "hunter2"})
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+	if len(hooked) != 3 || hooked[0] != "card ***" {
+		t.Errorf("Incorrect output. Expected redacted values, received '%v'", hooked)
+	}
+}
+
+func TestLoggerRedactorFormat(t *testing.T) {
+
+	type user struct {
+		Name     string
+		Password string
+		PIN      int `plog:"redact"`
+	}
+
+	tests := []struct {
+		format    string
+		variables []interface{}
+		expected  string
+	}{
+		{"%+v", []interface{}{user{"alice", "hunter2", 1234}}, "{Name:alice Password:*** PIN:0}"},
+		{"user %v", []interface{}{&user{"alice", "hunter2", 1234}}, "user &{alice *** 0}"},
+		{"token=%v", []interface{}{"Bearer abc.def.ghi"}, "token=***"},
+		{"Bearer %s", []interface{}{"abc.def.ghi"}, "***"},
+		{"%v", []interface{}{map[string]string{"password": "hunter2"}}, "map[password:***]"},
+	}
+
+	for i, test := range tests {
+
+		// Create a logger that only writes the message
+		var buffer bytes.Buffer
+		var hooked string
+		logger := NewLogger(
+			WithOutput(&buffer),
+			WithRecordFormatter(formatters.PlainRecord),
+			WithRedactor(NewRedactor(
+				WithRedactPatterns(BearerTokenPattern),
+				WithRedactKeys("password"),
+				WithRedactMask("***"),
+			)),
+			WithHook(func(log *Log) {
+				hooked = log.Variables()[0].(string)
+			}),
+		)
+
+		logger.Infof(test.format, test.variables...)
+
+		// Check if the output is correct
+		if output := buffer.String(); output != test.expected+"\n" {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, test.expected+"\n", output)
+		}
+		if hooked != test.expected {
+			t.Errorf("[%d] Incorrect hook output.\n\tExpected: '%s'\n\tReceived: '%s'", i, test.expected, hooked)
+		}
+	}
+}
@@ -10,6 +10,19 @@ var Now = time.Now
 
 // DateTime will set the name of the file to the current date/time.
 // NOTE: We're using microseconds here to make sure that file names don't conflict if they're generated too quickly.
+// Use `NewDateTime` to name files using a different clock.
 func DateTime(format, prev string) (next string, err error) {
-	return fmt.Sprintf(format, Now().UTC().Format("2006-01-02T15:04:05.000Z07:00")), nil
+	return dateTime(Now, format)
+}
+
+// NewDateTime will return a DateTime sequencer that reads the current time from the given clock (e.g. a `mocks.Clock`).
+func NewDateTime(clock func() time.Time) func(format, prev string) (next string, err error) {
+	return func(format, prev string) (next string, err error) {
+		return dateTime(clock, format)
+	}
+}
+
+// dateTime will format the file name using the time returned by the given clock.
+func dateTime(clock func() time.Time, format string) (next string, err error) {
+	return fmt.Sprintf(format, clock().UTC().Format("2006-01-02T15:04:05.000Z07:00")), nil
 }
@@ -2,6 +2,7 @@ package sequencers
 
 import (
 	"testing"
+	"time"
 
 	"github.com/pd93/plog/mocks"
 )
@@ -25,3 +26,24 @@ func TestDateTime(t *testing.T) {
 		t.Errorf("Expected: '%s'\nReceived: '%s'", expected, next)
 	}
 }
+
+func TestNewDateTime(t *testing.T) {
+
+	// Create a sequencer using a fake clock
+	clock := mocks.NewClock(mocks.Now())
+	clock.Advance(time.Second)
+
+	// Expected output
+	const expected = "test-2006-01-02T15:04:06.000Z"
+
+	// Call the function
+	next, err := NewDateTime(clock.Now)("test-%s", "")
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	// Check if the output is correct
+	if next != expected {
+		t.Errorf("Expected: '%s'\nReceived: '%s'", expected, next)
+	}
+}
@@ -0,0 +1,110 @@
+package plog
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+//
+// Structures
+//
+
+// LoggerStats holds a snapshot of a logger's counters.
+type LoggerStats struct {
+	Records  map[LogLevel]uint64 `json:"records"`        // The number of logs written at each log level
+	Filtered uint64              `json:"filtered"`       // The number of logs not written because of the logger's log level
+	Bytes    uint64              `json:"bytes"`          // The number of bytes written to the output
+	Errors   uint64              `json:"errors"`         // The number of logs that could not be formatted or written
+	File     *FileStats          `json:"file,omitempty"` // The stats of the logger's output if it is a file
+}
+
+// FileStats holds a snapshot of a file's counters.
+type FileStats struct {
+	Name      string `json:"name"`      // The name of the currently open file
+	Rotations uint64 `json:"rotations"` // The number of times the file has been rotated
+	Bytes     uint64 `json:"bytes"`     // The number of bytes written to the file
+	Errors    uint64 `json:"errors"`    // The number of writes or rotations that failed
+}
+
+// loggerCounters holds the live counters for a logger.
+// The counters are updated atomically, so they must stay at the start of the struct to be 64-bit aligned.
+type loggerCounters struct {
+	records  [TraceLevel + 1]uint64
+	filtered uint64
+	bytes    uint64
+	errors   uint64
+}
+
+// fileCounters holds the live counters for a file.
+// The counters are updated atomically, so they must stay at the start of the struct to be 64-bit aligned.
+type fileCounters struct {
+	rotations uint64
+	bytes     uint64
+	errors    uint64
+}
+
+// Publish the stats of every global logger via expvar.
+// expvar does not allow variables to be removed, so a single variable lists the loggers that are currently added.
+func init() {
+	expvar.Publish("plog", expvar.Func(func() interface{} {
+
+		loggersMutex.RLock()
+		defer loggersMutex.RUnlock()
+
+		stats := make(map[string]LoggerStats, len(loggers))
+		for name, logger := range loggers {
+			stats[name] = logger.Stats()
+		}
+
+		return stats
+	}))
+}
+
+//
+// Getters
+//
+
+// Stats will return a snapshot of the logger's counters.
+// The stats of every global logger are also published via expvar under the 'plog' variable, keyed by logger name.
+func (logger *Logger) Stats() (stats LoggerStats) {
+
+	stats = LoggerStats{
+		Records:  make(map[LogLevel]uint64, TraceLevel),
+		Filtered: atomic.LoadUint64(&logger.counters.filtered),
+		Bytes:    atomic.LoadUint64(&logger.counters.bytes),
+		Errors:   atomic.LoadUint64(&logger.counters.errors),
+	}
+
+	// Loop through the log levels and count the logs
+	for logLevel := FatalLevel; logLevel <= TraceLevel; logLevel++ {
+		stats.Records[logLevel] = atomic.LoadUint64(&logger.counters.records[logLevel])
+	}
+
+	// Include the stats of the output if it is a file
+	if file, ok := logger.output.(*File); ok {
+		fileStats := file.Stats()
+		stats.File = &fileStats
+	}
+
+	return
+}
+
+// Stats will return a snapshot of the file's counters.
+func (file *File) Stats() (stats FileStats) {
+
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+
+	stats = FileStats{
+		Rotations: atomic.LoadUint64(&file.counters.rotations),
+		Bytes:     atomic.LoadUint64(&file.counters.bytes),
+		Errors:    atomic.LoadUint64(&file.counters.errors),
+	}
+
+	// Get the name of the currently open file
+	if file.File != nil {
+		stats.Name = file.Name()
+	}
+
+	return
+}
@@ -0,0 +1,103 @@
+package plog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pd93/plog/sequencers"
+)
+
+// errorWriter fails every write.
+type errorWriter struct{}
+
+func (errorWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("Test error")
+}
+
+func TestLoggerStats(t *testing.T) {
+
+	// Create a logger
+	var buffer bytes.Buffer
+	logger := NewLogger(WithOutput(&buffer), WithColorLogging(false))
+
+	// Write some logs
+	logger.Info("Test string")
+	logger.Infof("Test string %d", 123)
+	logger.Warn("Test string")
+	logger.Debug("Test string")
+	logger.Trace("Test string")
+
+	// Write a log that fails
+	logger.Options(WithOutput(errorWriter{}))
+	logger.Error(errors.New("Test error"))
+
+	stats := logger.Stats()
+
+	// Check if the stats are correct
+	if stats.Records[InfoLevel] != 2 || stats.Records[WarnLevel] != 1 || stats.Records[ErrorLevel] != 0 {
+		t.Errorf("Incorrect records: %v", stats.Records)
+	}
+	if stats.Filtered != 2 {
+		t.Errorf("Incorrect filtered count. Expected 2, received %d", stats.Filtered)
+	}
+	if stats.Bytes != uint64(buffer.Len()) {
+		t.Errorf("Incorrect byte count. Expected %d, received %d", buffer.Len(), stats.Bytes)
+	}
+	if stats.Errors != 1 {
+		t.Errorf("Incorrect error count. Expected 1, received %d", stats.Errors)
+	}
+}
+
+func TestFileStats(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "plog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Create a file that rotates after every message
+	file, err := NewFile(filepath.Join(dir, "log-%d.txt"),
+		WithSequencer(sequencers.Increment),
+		WithMaxFileSize(16),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	// Write to a global logger
+	AddLogger("stats", NewLogger(WithOutput(file), WithFormatter(plainFormatter)))
+	defer DeleteLogger("stats")
+	for i := 0; i < 3; i++ {
+		Info("Test string")
+	}
+
+	stats := GetLogger("stats").Stats()
+
+	// Check if the stats are correct
+	if stats.File == nil {
+		t.Fatal("Expected file stats")
+	}
+	if stats.File.Rotations != 2 || stats.File.Bytes != 36 || stats.File.Errors != 0 {
+		t.Errorf("Incorrect file stats: %+v", *stats.File)
+	}
+	if filepath.Base(stats.File.Name) != "log-2.txt" {
+		t.Errorf("Incorrect file name. Expected 'log-2.txt', received '%s'", filepath.Base(stats.File.Name))
+	}
+
+	// Check that the stats are published via expvar
+	var published map[string]LoggerStats
+	if err := json.Unmarshal([]byte(expvar.Get("plog").String()), &published); err != nil {
+		t.Fatal(err)
+	}
+	if published["stats"].Records[InfoLevel] != 3 {
+		t.Errorf("Incorrect published stats: %s", expvar.Get("plog").String())
+	}
+}
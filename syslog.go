@@ -0,0 +1,441 @@
+package plog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultSyslogBufferSize is the number of logs that are kept while a syslog server cannot be reached.
+const DefaultSyslogBufferSize = 1000
+
+// DefaultSyslogTimeout is how long the output waits to connect to the server or to send a message.
+const DefaultSyslogTimeout = 5 * time.Second
+
+// The time to wait before reconnecting to a syslog server. The wait is doubled after each failed attempt.
+const (
+	minSyslogRetryWait = time.Second
+	maxSyslogRetryWait = time.Minute
+)
+
+// An undeliverableError is returned when messages are dropped because they could never be sent (e.g. a datagram that is too large).
+type undeliverableError struct {
+	count int
+	err   error
+}
+
+// Error will return the number of messages that were dropped and the error that caused the last one to be dropped.
+func (err undeliverableError) Error() string {
+	return fmt.Sprintf("%d logs could not be sent and were dropped: %v", err.count, err.err)
+}
+
+// The paths of the local syslog socket on different platforms.
+var localSyslogPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+//
+// Structures
+//
+
+// SyslogOutput sends syslog messages to a syslog server (e.g. rsyslog) or the local syslog socket.
+// Messages sent over TCP use octet-counting framing (RFC 6587). Messages sent over a unix stream socket are separated by newlines,
+// so any newlines inside a message are escaped. Messages sent over UDP or a unix datagram socket are sent as they are.
+// If the server cannot be reached, logs are kept and sent once the output has reconnected.
+// Logs that fail for any other reason (e.g. a datagram that is too large) can never be sent, so they are dropped instead.
+// The output waits longer between each attempt to reconnect, so logging is not slowed down by a server that is down.
+// It should be used with the `formatters.RFC5424` or `formatters.RFC3164` formatters.
+type SyslogOutput struct {
+	network    string
+	address    string
+	bufferSize int
+	timeout    time.Duration
+	conn       net.Conn
+	connected  string // The network of the current connection
+	pending    [][]byte
+	dropped    uint64
+	retryWait  time.Duration
+	retryAt    time.Time
+	mutex      sync.Mutex
+}
+
+// A SyslogOption is a function that sets an option on a given syslog output.
+type SyslogOption func(output *SyslogOutput)
+
+//
+// Constructors
+//
+
+// NewSyslogOutput will create a syslog output and connect it to the given address.
+// The network must be "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "unix" or "unixgram".
+// If the network is empty, the output connects to the local syslog socket (e.g. `/dev/log`) and the address is ignored.
+// Any number of additional functional options can be passed to this method and they will be applied on creation.
+// You can read more information on functional options on the PLog wiki: https://github.com/pd93/plog/wiki/Functional-Options.
+func NewSyslogOutput(network, address string, opts ...SyslogOption) (output *SyslogOutput, err error) {
+
+	// Check that the network is supported
+	switch network {
+	case "", "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6", "unix", "unixgram":
+	default:
+		return nil, fmt.Errorf("Unsupported syslog network: '%s'", network)
+	}
+
+	// Create a default syslog output
+	output = &SyslogOutput{
+		network:    network,
+		address:    address,
+		bufferSize: DefaultSyslogBufferSize,
+		timeout:    DefaultSyslogTimeout,
+	}
+
+	// Apply the custom options
+	output.Options(opts...)
+
+	// Connect to the server
+	if err = output.dial(); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+//
+// Functional Options
+//
+
+// WithSyslogBufferSize will return a function that sets the number of logs that are kept while the server cannot be reached.
+// If more logs are written, the oldest logs are dropped and an error is returned.
+func WithSyslogBufferSize(bufferSize int) SyslogOption {
+	return func(output *SyslogOutput) {
+		output.bufferSize = bufferSize
+	}
+}
+
+// WithSyslogTimeout will return a function that sets how long the output waits to connect to the server or to send a message.
+// Messages are written while the logger is locked, so a server that stops responding will only block logging for this long.
+// If the timeout is 0, the output waits forever.
+func WithSyslogTimeout(timeout time.Duration) SyslogOption {
+	return func(output *SyslogOutput) {
+		output.timeout = timeout
+	}
+}
+
+//
+// Options Setter
+//
+
+// Options will apply the given options to the syslog output.
+// Any number of functional options can be passed to this method.
+// You can read more information on functional options on the PLog wiki: https://github.com/pd93/plog/wiki/Functional-Options.
+func (output *SyslogOutput) Options(opts ...SyslogOption) {
+
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+
+	// Loop through each option and apply it
+	for _, opt := range opts {
+		opt(output)
+	}
+
+	// At least the current log must be kept
+	if output.bufferSize < 1 {
+		output.bufferSize = 1
+	}
+}
+
+//
+// Getters
+//
+
+// Network will return the network that the syslog output sends messages over.
+func (output *SyslogOutput) Network() string {
+	return output.network
+}
+
+// Address will return the address that the syslog output sends messages to.
+func (output *SyslogOutput) Address() string {
+	return output.address
+}
+
+// BufferSize will return the number of logs that are kept while the server cannot be reached.
+func (output *SyslogOutput) BufferSize() int {
+	return output.bufferSize
+}
+
+// Timeout will return how long the syslog output waits to connect to the server or to send a message.
+func (output *SyslogOutput) Timeout() time.Duration {
+	return output.timeout
+}
+
+// Pending will return the number of logs that are waiting to be sent.
+func (output *SyslogOutput) Pending() int {
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+	return len(output.pending)
+}
+
+// Dropped will return the number of logs that were dropped because the buffer was full or they could never be sent.
+func (output *SyslogOutput) Dropped() uint64 {
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+	return output.dropped
+}
+
+//
+// Instance methods
+//
+
+// Write will send a single syslog message. Any trailing newline is removed.
+// If the message cannot be sent, it is kept with any other pending messages and an error is returned.
+// Pending messages are sent in order once the output has reconnected.
+// After a failed attempt to reconnect, messages are kept without reconnecting until the output has waited long enough to try again.
+func (output *SyslogOutput) Write(p []byte) (n int, err error) {
+
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+
+	// Remove the newline added by the logger
+	end := len(p)
+	for end > 0 && (p[end-1] == '\n' || p[end-1] == '\r') {
+		end--
+	}
+
+	// Add a copy of the message to the pending messages, dropping the oldest if the buffer is full
+	var dropped int
+	for len(output.pending) >= output.bufferSize {
+		output.pending[0] = nil
+		output.pending = output.pending[1:]
+		dropped++
+	}
+	output.dropped += uint64(dropped)
+	output.pending = append(output.pending, append([]byte(nil), p[:end]...))
+
+	// Send the pending messages
+	if err = output.flush(false); err != nil {
+		if undeliverable, ok := err.(undeliverableError); ok {
+			return len(p), fmt.Errorf("Syslog server rejected logs, %d logs dropped: %v", undeliverable.count+dropped, undeliverable.err)
+		}
+		return 0, fmt.Errorf("Syslog server unavailable, %d logs pending and %d dropped: %v", len(output.pending), dropped, err)
+	}
+	if dropped > 0 {
+		return len(p), fmt.Errorf("Syslog buffer full, %d logs dropped", dropped)
+	}
+
+	return len(p), nil
+}
+
+// Flush will try to send any pending messages.
+// If the output is not connected, it reconnects immediately.
+func (output *SyslogOutput) Flush() error {
+
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+
+	return output.flush(true)
+}
+
+// Close will try to send any pending messages and close the connection to the server.
+func (output *SyslogOutput) Close() error {
+
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+
+	err := output.flush(true)
+	if output.conn != nil {
+		output.conn.Close()
+		output.conn = nil
+	}
+
+	return err
+}
+
+// flush will send each pending message in order.
+// If a message cannot be sent, the output reconnects once and tries again.
+// Messages that can never be sent are dropped and reported with an undeliverableError once the other messages have been sent.
+// Unless force is true, the output won't reconnect until the wait after the last failed attempt has passed.
+func (output *SyslogOutput) flush(force bool) error {
+
+	if len(output.pending) == 0 {
+		return nil
+	}
+
+	// Wait before reconnecting to a server that is down
+	if output.conn == nil && !force && time.Now().Before(output.retryAt) {
+		return fmt.Errorf("reconnecting in %s", time.Until(output.retryAt).Round(time.Millisecond))
+	}
+
+	undeliverable, err := output.sendPending()
+	output.dropped += uint64(undeliverable.count)
+	if err != nil {
+		output.retryLater()
+		return err
+	}
+
+	// Reuse the buffer
+	output.pending = output.pending[:0]
+	output.retryWait = 0
+
+	if undeliverable.count > 0 {
+		return undeliverable
+	}
+
+	return nil
+}
+
+// retryLater will close the connection and set when the output should try to reconnect.
+func (output *SyslogOutput) retryLater() {
+
+	if output.conn != nil {
+		output.conn.Close()
+		output.conn = nil
+	}
+
+	// Double the wait after each failed attempt
+	output.retryWait *= 2
+	if output.retryWait < minSyslogRetryWait {
+		output.retryWait = minSyslogRetryWait
+	}
+	if output.retryWait > maxSyslogRetryWait {
+		output.retryWait = maxSyslogRetryWait
+	}
+	output.retryAt = time.Now().Add(output.retryWait)
+}
+
+// sendPending will send each pending message in order, reconnecting once if a message cannot be sent.
+// Only connection errors and timeouts are retried. Any other error means that the message can never be sent, so it is dropped.
+// The dropped messages are returned, along with the error that stopped the messages from being sent (if any).
+func (output *SyslogOutput) sendPending() (undeliverable undeliverableError, err error) {
+
+	reconnected := false
+	for len(output.pending) > 0 {
+
+		// Connect if the connection has failed or the server has closed it
+		if output.conn != nil && output.isStream() && isConnClosed(output.conn) {
+			output.conn.Close()
+			output.conn = nil
+		}
+		if output.conn == nil {
+			if reconnected {
+				return undeliverable, errors.New("connection closed")
+			}
+			if err = output.dial(); err != nil {
+				return
+			}
+			reconnected = true
+		}
+
+		// Send the oldest message
+		if err = output.send(output.pending[0]); err != nil {
+
+			// Drop messages that can never be sent so that they don't block the messages after them
+			// A stream may have been sent part of the message, so it is closed
+			if !isRetryable(err) {
+				undeliverable.count++
+				undeliverable.err = err
+				output.pending[0] = nil
+				output.pending = output.pending[1:]
+				if output.isStream() {
+					output.conn.Close()
+					output.conn = nil
+				}
+				continue
+			}
+
+			output.conn.Close()
+			output.conn = nil
+			if reconnected {
+				return
+			}
+			continue
+		}
+		output.pending[0] = nil
+		output.pending = output.pending[1:]
+	}
+
+	return undeliverable, nil
+}
+
+// isRetryable will return whether or not sending a message might succeed after reconnecting.
+// This is only the case for timeouts and errors caused by the connection.
+func isRetryable(err error) bool {
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, net.ErrClosed) || errors.Is(err, io.EOF) || isConnError(err)
+}
+
+// send will send a message using the framing of the output's network.
+func (output *SyslogOutput) send(message []byte) (err error) {
+
+	// Don't block the logger if the server stops reading
+	if output.timeout > 0 {
+		if err = output.conn.SetWriteDeadline(time.Now().Add(output.timeout)); err != nil {
+			return
+		}
+	}
+
+	switch output.connected {
+
+	// TCP messages are prefixed with their length
+	case "tcp", "tcp4", "tcp6":
+		frame := strconv.AppendInt(make([]byte, 0, len(message)+8), int64(len(message)), 10)
+		frame = append(append(frame, ' '), message...)
+		_, err = output.conn.Write(frame)
+
+	// Unix stream messages are separated by newlines, so newlines inside the message are escaped
+	case "unix":
+		frame := make([]byte, 0, len(message)+1+bytes.Count(message, []byte{'\n'}))
+		for _, c := range message {
+			if c == '\n' {
+				frame = append(frame, '\\', 'n')
+				continue
+			}
+			frame = append(frame, c)
+		}
+		_, err = output.conn.Write(append(frame, '\n'))
+
+	// Datagrams contain a single message
+	default:
+		_, err = output.conn.Write(message)
+	}
+
+	return
+}
+
+// dial will connect to the server or the local syslog socket.
+func (output *SyslogOutput) dial() (err error) {
+
+	if output.network != "" {
+		output.conn, err = net.DialTimeout(output.network, output.address, output.timeout)
+		output.connected = output.network
+		return
+	}
+
+	// Try each of the local syslog sockets
+	for _, path := range localSyslogPaths {
+		for _, network := range []string{"unixgram", "unix"} {
+			if output.conn, err = net.DialTimeout(network, path, output.timeout); err == nil {
+				output.connected = network
+				return nil
+			}
+		}
+	}
+
+	return errors.New("Unable to connect to the local syslog socket")
+}
+
+// isStream will return whether or not the output is connected with a stream.
+func (output *SyslogOutput) isStream() bool {
+	switch output.connected {
+	case "tcp", "tcp4", "tcp6", "unix":
+		return true
+	default:
+		return false
+	}
+}
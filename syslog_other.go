@@ -0,0 +1,16 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd,!windows
+
+package plog
+
+import "net"
+
+// isConnClosed will always return false as closed connections are only detected when writing fails on this platform.
+func isConnClosed(conn net.Conn) bool {
+	return false
+}
+
+// isConnError will always return true as errors cannot be told apart on this platform, so every error is retried.
+func isConnError(err error) bool {
+	return true
+}
@@ -0,0 +1,300 @@
+package plog
+
+import (
+	"bufio"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pd93/plog/formatters"
+	"github.com/pd93/plog/mocks"
+)
+
+func TestSyslogOutputUDP(t *testing.T) {
+
+	// Start a local UDP listener
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Create a syslog logger
+	output, err := NewSyslogOutput("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+	logger := NewSyslogLogger(output, WithClock(mocks.Now), WithRecordFormatter(formatters.NewRFC5424(
+		formatters.WithHostname("host1"),
+		formatters.WithAppName("app"),
+		formatters.WithProcID("123"),
+		formatters.WithEnterpriseID("12345"),
+	)))
+
+	logger.TInfo(Tags{"tag1"}, "Test string", NewField("user", "alice"))
+
+	// Check if the output is correct
+	const expected = `<14>1 2006-01-02T15:04:05Z host1 app 123 - [tags@12345 tag="tag1"][fields@12345 user="alice"] Test string`
+	buffer := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output := string(buffer[:n]); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestSyslogOutputUndeliverable(t *testing.T) {
+
+	if runtime.GOOS == "plan9" || runtime.GOOS == "js" {
+		t.Skip("Send errors can't be told apart on " + runtime.GOOS)
+	}
+
+	// Start a local UDP listener
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Create a syslog output
+	output, err := NewSyslogOutput("udp", conn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	// A datagram that is too large can never be sent, so it should be dropped instead of blocking the messages after it
+	if _, err := output.Write(make([]byte, 70000)); err == nil {
+		t.Error("Incorrect output. Expected an error for a message that is too large")
+	}
+	for i := 0; i < 5; i++ {
+		if _, err := output.Write([]byte("Test string " + strconv.Itoa(i))); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// Check if the output is correct
+	buffer := make([]byte, 1024)
+	for i := 0; i < 5; i++ {
+		expected := "Test string " + strconv.Itoa(i)
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if received := string(buffer[:n]); received != expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", i, expected, received)
+		}
+	}
+	if pending, dropped := output.Pending(), output.Dropped(); pending != 0 || dropped != 1 {
+		t.Errorf("Incorrect output. Expected 0 pending and 1 dropped, received %d pending and %d dropped", pending, dropped)
+	}
+}
+
+func TestSyslogOutputTCP(t *testing.T) {
+
+	// Start a local TCP listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// Read octet-counted messages from each connection
+	messages := make(chan string)
+	conns := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns <- conn
+			go func() {
+				reader := bufio.NewReader(conn)
+				for {
+					length, err := reader.ReadString(' ')
+					if err != nil {
+						return
+					}
+					n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+					if err != nil {
+						messages <- "invalid frame: " + length
+						return
+					}
+					message := make([]byte, n)
+					if _, err := reader.Read(message); err != nil {
+						return
+					}
+					messages <- string(message)
+				}
+			}()
+		}
+	}()
+
+	// Create a syslog logger
+	output, err := NewSyslogOutput("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+	logger := NewSyslogLogger(output, WithClock(mocks.Now), WithRecordFormatter(formatters.NewRFC3164(
+		formatters.WithHostname("host1"),
+		formatters.WithAppName("app"),
+		formatters.WithProcID("123"),
+	)))
+
+	// Write a log
+	logger.Warn("Test string\nwith two lines")
+	expected := "<12>Jan  2 15:04:05 host1 app[123]: Test string\nwith two lines"
+	if output := receiveMessage(t, messages); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expected, output)
+	}
+
+	// The server closing the connection should not lose the next log
+	(<-conns).Close()
+	time.Sleep(50 * time.Millisecond)
+	logger.Info("Test string")
+	expected = "<14>Jan  2 15:04:05 host1 app[123]: Test string"
+	if output := receiveMessage(t, messages); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expected, output)
+	}
+}
+
+func TestSyslogOutputReconnect(t *testing.T) {
+
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" || runtime.GOOS == "js" {
+		t.Skip("Unix sockets are not supported on " + runtime.GOOS)
+	}
+
+	// Start a local unix socket listener
+	dir, err := ioutil.TempDir("", "plog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "log")
+	conn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a syslog output
+	output, err := NewSyslogOutput("unixgram", path, WithSyslogBufferSize(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	// Stop the server. Logs should be kept and an error returned
+	conn.Close()
+	os.Remove(path)
+	for i, message := range []string{"one\n", "two\n", "three\n", "four\n"} {
+		if _, err := output.Write([]byte(message)); err == nil {
+			t.Errorf("[%d] Incorrect output. Expected an error, received nil", i)
+		}
+	}
+	if pending, dropped := output.Pending(), output.Dropped(); pending != 3 || dropped != 1 {
+		t.Errorf("Incorrect output. Expected 3 pending and 1 dropped, received %d pending and %d dropped", pending, dropped)
+	}
+
+	// Restart the server. The output should wait before reconnecting
+	if conn, err = net.ListenPacket("unixgram", path); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := output.Write([]byte("five\n")); err == nil {
+		t.Error("Incorrect output. Expected an error, received nil")
+	}
+
+	// Flushing should reconnect immediately and send the pending logs in order
+	if err := output.Flush(); err != nil {
+		t.Error(err)
+	}
+	buffer := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for _, expected := range []string{"three", "four", "five"} {
+		n, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output := string(buffer[:n]); output != expected {
+			t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+		}
+	}
+	if pending := output.Pending(); pending != 0 {
+		t.Errorf("Incorrect output. Expected 0 pending, received %d", pending)
+	}
+}
+
+func TestSyslogOutputUnix(t *testing.T) {
+
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" || runtime.GOOS == "js" {
+		t.Skip("Unix sockets are not supported on " + runtime.GOOS)
+	}
+
+	// Start a local unix stream listener
+	dir, err := ioutil.TempDir("", "plog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "log")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// Read lines from the first connection
+	messages := make(chan string)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			message, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			messages <- message
+		}
+	}()
+
+	// Create a syslog output
+	output, err := NewSyslogOutput("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer output.Close()
+
+	// Newlines inside a message should not split it
+	if _, err := output.Write([]byte("line1\nline2\n")); err != nil {
+		t.Error(err)
+	}
+	expected := "line1\\nline2\n"
+	if output := receiveMessage(t, messages); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expected, output)
+	}
+}
+
+func TestNewSyslogOutput(t *testing.T) {
+
+	// Unsupported networks should return an error
+	if _, err := NewSyslogOutput("ip", "127.0.0.1"); err == nil {
+		t.Error("Incorrect output. Expected an error, received nil")
+	}
+}
@@ -0,0 +1,53 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+// +build linux darwin dragonfly freebsd netbsd openbsd
+
+package plog
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// isConnClosed will return whether or not the other end of a stream connection has closed it.
+// Writes to a closed connection can succeed, so this is checked before writing to avoid losing logs.
+// Syslog servers never send data, so a read that does not block means that the connection has been closed.
+func isConnClosed(conn net.Conn) bool {
+
+	sysConn, ok := conn.(syscall.Conn)
+	if !ok {
+		return false
+	}
+	rawConn, err := sysConn.SyscallConn()
+	if err != nil {
+		return false
+	}
+
+	// Read from the socket without blocking
+	var closed bool
+	var buffer [1]byte
+	err = rawConn.Read(func(fd uintptr) bool {
+		n, err := syscall.Read(int(fd), buffer[:])
+		closed = n == 0 && err == nil || err != nil && err != syscall.EAGAIN && err != syscall.EWOULDBLOCK && err != syscall.EINTR
+		return true
+	})
+
+	return closed || err != nil
+}
+
+// isConnError will return whether or not an error was caused by the connection to the server (e.g. the connection was reset).
+func isConnError(err error) bool {
+
+	var errno syscall.Errno
+	if !errors.As(err, &errno) {
+		return false
+	}
+
+	switch errno {
+	case syscall.ECONNREFUSED, syscall.ECONNRESET, syscall.ECONNABORTED, syscall.EPIPE, syscall.ENOTCONN,
+		syscall.ENETDOWN, syscall.ENETUNREACH, syscall.EHOSTUNREACH, syscall.ENOBUFS, syscall.EAGAIN:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,21 @@
+package plog
+
+import (
+	"errors"
+	"net"
+	"syscall"
+)
+
+// The Windows Sockets error returned when a datagram is larger than the socket allows.
+const wsaEMSGSIZE = syscall.Errno(10040)
+
+// isConnClosed will always return false as closed connections are only detected when writing fails on this platform.
+func isConnClosed(conn net.Conn) bool {
+	return false
+}
+
+// isConnError will return whether or not an error may have been caused by the connection to the server.
+// Windows Sockets errors have their own numbers, so every error is retried except messages that are too large to send.
+func isConnError(err error) bool {
+	return !errors.Is(err, wsaEMSGSIZE)
+}
@@ -1,5 +1,7 @@
 package plog
 
+import "github.com/pd93/plog/formatters"
+
 // A Tag is a metadata string that can be assigned to any log message.
 type Tag string
 
@@ -8,13 +10,38 @@ func (tag Tag) String(colorLogging bool, tagColorMap TagColorMap) string {
 
 	// Check if color logging is enabled and whether there is a color for this tag in the map
 	if attributes, ok := tagColorMap[tag]; colorLogging && ok {
-		return Color(string(tag), attributes...)
+		return string(Color(string(tag), attributes...))
 	}
 
 	// If there is no entry in the map, but color logging is still enabled
 	if colorLogging {
-		return Color(string(tag), FgWhite, Faint)
+		return string(Color(string(tag), FgWhite, Faint))
 	}
 
 	return string(tag)
 }
+
+// style will return the style for the tag if color logging is enabled.
+// Tags that are not in the tag color map are given a color from the palette if there is one, or the default attributes otherwise.
+func (tag Tag) style(colorLogging bool, tagColorMap TagColorMap, palette *tagPalette, defaultAttributes []Attribute) formatters.Style {
+
+	// If color logging is disabled, there is no style
+	if !colorLogging {
+		return ""
+	}
+
+	// Check whether there is a color for this tag in the map
+	if attributes, ok := tagColorMap[tag]; ok {
+		return newStyle(attributes)
+	}
+
+	// Check whether the tag can be given a color from the palette
+	if palette != nil {
+		if attribute, ok := palette.get(tag); ok {
+			attributes := [1]Attribute{attribute}
+			return newStyle(attributes[:])
+		}
+	}
+
+	return newStyle(defaultAttributes)
+}
@@ -0,0 +1,141 @@
+package plog
+
+// Background describes the background color of the terminal.
+// Automatic tag colors avoid colors that are hard to read on the background.
+type Background int
+
+// Available backgrounds:
+const (
+	// UnknownBackground allows any color to be used
+	UnknownBackground Background = iota
+	// DarkBackground avoids dark colors
+	DarkBackground
+	// LightBackground avoids light colors
+	LightBackground
+)
+
+// Thresholds used when choosing automatic tag colors.
+const (
+	minLevelDistance = 90 * 90 // The minimum squared distance from any log level color
+	minSaturation    = 80      // The minimum difference between the strongest and weakest channel (excludes grays)
+	minLumaOnDark    = 50      // Colors darker than this are hard to read on a dark background
+	maxLumaOnLight   = 170     // Colors lighter than this are hard to read on a light background
+)
+
+// A tagPalette is the list of colors that can be given to tags that are not in the tag color map.
+// The palette depends on the color profile, so it is rebuilt if the profile changes.
+type tagPalette struct {
+	built      bool
+	profile    ColorProfile
+	attributes []Attribute
+}
+
+// newTagPalette will build a palette of colors that are not similar to any of the log level colors.
+// Terminals with 256 or more colors use the 256 color cube, otherwise the basic colors are used.
+func newTagPalette(profile ColorProfile, logLevelColorMap LogLevelColorMap, background Background) tagPalette {
+
+	palette := tagPalette{built: true, profile: profile}
+
+	// Find the RGB value of each log level's foreground colors
+	var levelColors [][3]int
+	for _, attributes := range logLevelColorMap {
+		for _, attribute := range attributes {
+			if r, g, b, ok := attribute.foregroundRGB(); ok {
+				levelColors = append(levelColors, [3]int{r, g, b})
+			}
+		}
+	}
+
+	// Choose the candidate colors
+	var candidates []Attribute
+	if profile == ANSI16 {
+		for n := uint8(0); n < 16; n++ {
+			candidates = append(candidates, Fg256(n))
+		}
+	} else {
+		for n := 16; n < 232; n++ {
+			candidates = append(candidates, Fg256(uint8(n)))
+		}
+	}
+
+	// Loop through the candidates and keep the readable colors that don't look like a log level
+candidates:
+	for _, candidate := range candidates {
+
+		r, g, b, _ := candidate.foregroundRGB()
+
+		// Skip grays
+		if saturation(r, g, b) < minSaturation {
+			continue
+		}
+
+		// Skip colors that are hard to read on the background
+		luma := (2126*r + 7152*g + 722*b) / 10000
+		if background == DarkBackground && luma < minLumaOnDark || background == LightBackground && luma > maxLumaOnLight {
+			continue
+		}
+
+		// Skip colors that are similar to a log level color
+		for _, color := range levelColors {
+			if distance(r, g, b, color[0], color[1], color[2]) < minLevelDistance {
+				continue candidates
+			}
+		}
+
+		palette.attributes = append(palette.attributes, candidate)
+	}
+
+	return palette
+}
+
+// get will return the color for the given tag. The same tag is always given the same color.
+// If the palette is empty, false is returned.
+func (palette tagPalette) get(tag Tag) (Attribute, bool) {
+
+	if len(palette.attributes) == 0 {
+		return Reset, false
+	}
+
+	// Hash the tag using 32-bit FNV-1a
+	hash := uint32(2166136261)
+	for i := 0; i < len(tag); i++ {
+		hash ^= uint32(tag[i])
+		hash *= 16777619
+	}
+
+	return palette.attributes[hash%uint32(len(palette.attributes))], true
+}
+
+// foregroundRGB will return the RGB value of the attribute if it is a foreground color.
+func (attribute Attribute) foregroundRGB() (r, g, b int, ok bool) {
+	switch {
+	case attribute&extendedColor != 0 && attribute&backgroundColor != 0:
+		return 0, 0, 0, false
+	case attribute&rgbColor != 0:
+		return int(attribute >> 16 & 0xff), int(attribute >> 8 & 0xff), int(attribute & 0xff), true
+	case attribute&extendedColor != 0:
+		r, g, b = ansi256ToRGB(int(attribute & 0xff))
+		return r, g, b, true
+	case attribute >= FgBlack && attribute <= FgWhite:
+		r, g, b = ansi256ToRGB(int(attribute - FgBlack))
+		return r, g, b, true
+	case attribute >= FgHiBlack && attribute <= FgHiWhite:
+		r, g, b = ansi256ToRGB(int(attribute-FgHiBlack) + 8)
+		return r, g, b, true
+	}
+	return 0, 0, 0, false
+}
+
+// saturation will return the difference between the strongest and weakest channel of an RGB color.
+func saturation(r, g, b int) int {
+	high, low := r, r
+	for _, c := range [2]int{g, b} {
+		if c > high {
+			high = c
+		}
+		if c < low {
+			low = c
+		}
+	}
+	return high - low
+}
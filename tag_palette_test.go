@@ -0,0 +1,102 @@
+package plog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pd93/plog/mocks"
+)
+
+type tagPaletteTest struct {
+	profile    ColorProfile
+	background Background
+}
+
+func TestTagPalette(t *testing.T) {
+
+	tests := []tagPaletteTest{
+		{ANSI16, UnknownBackground},
+		{ANSI16, DarkBackground},
+		{ANSI256, UnknownBackground},
+		{ANSI256, DarkBackground},
+		{ANSI256, LightBackground},
+		{TrueColor, LightBackground},
+	}
+
+	logLevelColorMap := NewLogLevelColorMap()
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		palette := newTagPalette(test.profile, logLevelColorMap, test.background)
+		if len(palette.attributes) == 0 {
+			t.Errorf("[%d] Expected the palette to contain colors", i)
+		}
+
+		// Loop through the colors in the palette and make sure they are usable
+		for _, attribute := range palette.attributes {
+
+			r, g, b, _ := attribute.foregroundRGB()
+			luma := (2126*r + 7152*g + 722*b) / 10000
+
+			if test.background == DarkBackground && luma < minLumaOnDark {
+				t.Errorf("[%d] Color '%s' is too dark for a dark background", i, attribute)
+			}
+			if test.background == LightBackground && luma > maxLumaOnLight {
+				t.Errorf("[%d] Color '%s' is too light for a light background", i, attribute)
+			}
+			for _, attributes := range logLevelColorMap {
+				for _, levelAttribute := range attributes {
+					if lr, lg, lb, ok := levelAttribute.foregroundRGB(); ok && distance(r, g, b, lr, lg, lb) < minLevelDistance {
+						t.Errorf("[%d] Color '%s' is too similar to the log level color '%s'", i, attribute, levelAttribute)
+					}
+				}
+			}
+		}
+
+		// The same tag should always get the same color
+		first, _ := palette.get("database")
+		second, _ := newTagPalette(test.profile, logLevelColorMap, test.background).get("database")
+		if first != second {
+			t.Errorf("[%d] Incorrect output. Expected '%s', received '%s'", i, first, second)
+		}
+	}
+}
+
+func TestLoggerAutoTagColors(t *testing.T) {
+
+	// Restore the color profile after the test
+	defer SetColorProfile(CurrentColorProfile())
+	SetColorProfile(ANSI256)
+
+	// Create a logger that colors unmapped tags automatically
+	var buffer bytes.Buffer
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithColorLogging(true),
+		WithClock(mocks.Now),
+		WithTagColorMap(NewTagColorMap(WithTagColorMapping("mapped", FgRed))),
+		WithAutoTagColors(true),
+	)
+
+	logger.TInfo(Tags{"mapped", "database", "http"}, "Test string")
+	logger.TInfo(Tags{"database"}, "Test string")
+	lines := strings.Split(buffer.String(), "\n")
+
+	// Mapped tags should keep their colors
+	if !strings.Contains(lines[0], "\x1b[31m#mapped\x1b[0m") {
+		t.Errorf("Incorrect output. Expected the mapped tag to be red, received '%q'", lines[0])
+	}
+
+	// Unmapped tags should be given a color from the 256 color palette, which is the same every time
+	if !strings.Contains(lines[0], "\x1b[38;5;") || strings.Contains(lines[0], "\x1b[37;2m") {
+		t.Errorf("Incorrect output. Expected unmapped tags to be colored automatically, received '%q'", lines[0])
+	}
+	database := newTagPalette(ANSI256, logger.LogLevelColorMap(), UnknownBackground)
+	attribute, _ := database.get("database")
+	expected := newStyle([]Attribute{attribute}).Apply("#database")
+	if !strings.Contains(lines[0], expected) || !strings.Contains(lines[1], expected) {
+		t.Errorf("Incorrect output. Expected '%q' in both lines, received '%q'", expected, lines[:2])
+	}
+}
@@ -15,3 +15,16 @@ func (tags Tags) String(colorLogging bool, tagColorMap TagColorMap) (strs []stri
 
 	return
 }
+
+// Strings will return the uncolored tags as a slice of strings.
+func (tags Tags) Strings() (strs []string) {
+
+	strs = make([]string, len(tags))
+
+	// Loop through the tags and convert them
+	for i, tag := range tags {
+		strs[i] = string(tag)
+	}
+
+	return
+}
@@ -0,0 +1,41 @@
+package plog
+
+import (
+	"io"
+	"os"
+)
+
+// detectColorLogging will return whether or not color should be used when writing to the given output.
+// The following conventions are checked in order:
+//   - NO_COLOR disables color if it is set to any value (See https://no-color.org)
+//   - FORCE_COLOR or CLICOLOR_FORCE enable color if they are set to anything other than '0' or 'false'
+//   - TERM=dumb disables color
+//
+// Otherwise, color is only used if the output is a terminal.
+func detectColorLogging(output io.Writer) bool {
+
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	for _, key := range []string{"FORCE_COLOR", "CLICOLOR_FORCE"} {
+		if value, ok := os.LookupEnv(key); ok {
+			return value != "0" && value != "false"
+		}
+	}
+
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return isTerminalWriter(output)
+}
+
+// isTerminalWriter will return whether or not the given output is a terminal.
+// Only outputs with a file descriptor (e.g. `*os.File`) can be terminals.
+func isTerminalWriter(output io.Writer) bool {
+	if file, ok := output.(interface{ Fd() uintptr }); ok {
+		return isTerminal(file.Fd())
+	}
+	return false
+}
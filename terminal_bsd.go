@@ -0,0 +1,9 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+// +build darwin dragonfly freebsd netbsd openbsd
+
+package plog
+
+import "syscall"
+
+// The ioctl request used to read terminal attributes.
+const ioctlReadTermios = syscall.TIOCGETA
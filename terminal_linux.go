@@ -0,0 +1,6 @@
+package plog
+
+import "syscall"
+
+// The ioctl request used to read terminal attributes.
+const ioctlReadTermios = syscall.TCGETS
@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !windows
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd,!windows
+
+package plog
+
+// isTerminal will always return false as terminals cannot be detected on this platform.
+func isTerminal(fd uintptr) bool {
+	return false
+}
@@ -0,0 +1,80 @@
+package plog
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+type colorDetectionTest struct {
+	env      map[string]string
+	expected bool
+}
+
+func TestDetectColorLogging(t *testing.T) {
+
+	tests := []colorDetectionTest{
+		{map[string]string{}, false},
+		{map[string]string{"FORCE_COLOR": "1"}, true},
+		{map[string]string{"FORCE_COLOR": "0"}, false},
+		{map[string]string{"FORCE_COLOR": "false"}, false},
+		{map[string]string{"CLICOLOR_FORCE": "1"}, true},
+		{map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"}, false},
+		{map[string]string{"TERM": "dumb"}, false},
+		{map[string]string{"TERM": "dumb", "FORCE_COLOR": "1"}, true},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Set the environment for this test
+		for _, key := range []string{"NO_COLOR", "FORCE_COLOR", "CLICOLOR_FORCE", "TERM"} {
+			t.Setenv(key, "")
+			if value, ok := test.env[key]; ok {
+				os.Setenv(key, value)
+			} else {
+				os.Unsetenv(key)
+			}
+		}
+
+		// A buffer is never a terminal
+		if output := detectColorLogging(&bytes.Buffer{}); output != test.expected {
+			t.Errorf("[%d] Incorrect output. Expected '%t', received '%t'", i, test.expected, output)
+		}
+	}
+}
+
+func TestAutoColorLogging(t *testing.T) {
+
+	t.Setenv("FORCE_COLOR", "")
+	os.Unsetenv("FORCE_COLOR")
+	t.Setenv("NO_COLOR", "")
+	os.Unsetenv("NO_COLOR")
+
+	// Pipes are not terminals
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+
+	logger := NewLogger(WithOutput(writer))
+	if logger.ColorLogging() {
+		t.Errorf("Incorrect output. Expected color logging to be disabled for a pipe")
+	}
+
+	// Color should be detected again when the options change
+	os.Setenv("FORCE_COLOR", "1")
+	logger.Options(WithOutput(&bytes.Buffer{}))
+	if !logger.ColorLogging() {
+		t.Errorf("Incorrect output. Expected color logging to be enabled by FORCE_COLOR")
+	}
+
+	// Setting color logging explicitly should override detection
+	logger.Options(WithColorLogging(false))
+	logger.Options(WithOutput(&bytes.Buffer{}))
+	if logger.ColorLogging() || logger.AutoColorLogging() {
+		t.Errorf("Incorrect output. Expected color logging to be disabled by WithColorLogging")
+	}
+}
@@ -0,0 +1,17 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+// +build linux darwin dragonfly freebsd netbsd openbsd
+
+package plog
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// isTerminal will return whether or not the given file descriptor is a terminal.
+// The terminal attributes can only be read from a terminal, so this avoids the need for cgo.
+func isTerminal(fd uintptr) bool {
+	var termios syscall.Termios
+	_, _, errno := syscall.Syscall6(syscall.SYS_IOCTL, fd, ioctlReadTermios, uintptr(unsafe.Pointer(&termios)), 0, 0, 0)
+	return errno == 0
+}
@@ -0,0 +1,9 @@
+package plog
+
+import "syscall"
+
+// isTerminal will return whether or not the given handle is a console.
+func isTerminal(fd uintptr) bool {
+	var mode uint32
+	return syscall.GetConsoleMode(syscall.Handle(fd), &mode) == nil
+}
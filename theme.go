@@ -0,0 +1,132 @@
+package plog
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// A Theme bundles the styles used to color each part of a log.
+// Themes can be shared as JSON documents where each attribute is written as its name (See `ParseAttribute`). e.g.
+//
+//	{
+//		"logLevels": {"ERROR": ["red", "bold"], "INFO": ["#859900"]},
+//		"tag": ["faint"],
+//		"timestamp": ["hi-black"]
+//	}
+type Theme struct {
+	LogLevels LogLevelColorMap `json:"logLevels"` // The style of each log level
+	Tags      TagColorMap      `json:"tags"`      // The style of specific tags
+	Tag       []Attribute      `json:"tag"`       // The style of any tag that is not in the tag color map
+	Timestamp []Attribute      `json:"timestamp"` // The style of the timestamp
+	Message   []Attribute      `json:"message"`   // The style of the message body
+	Separator []Attribute      `json:"separator"` // The style of the brackets around the log level and tags
+
+	// Styles for specific log levels. These take precedence over the styles above
+	Timestamps LogLevelColorMap `json:"timestamps"` // The style of the timestamp for each log level
+	Messages   LogLevelColorMap `json:"messages"`   // The style of the message body for each log level
+	Lines      LogLevelColorMap `json:"lines"`      // The style of the entire line for each log level
+}
+
+// LevelStyling lists the parts of a log that are styled using the log level color map.
+// This is an easier way of styling by log level than setting the theme's log level maps.
+type LevelStyling int
+
+// Parts of a log that can be styled by log level:
+const (
+	// LevelStyledTimestamp styles the timestamp using the log level's colors
+	LevelStyledTimestamp LevelStyling = 1 << iota
+	// LevelStyledMessage styles the message body using the log level's colors
+	LevelStyledMessage
+	// LevelStyledLine styles the entire line using the log level's colors
+	LevelStyledLine
+)
+
+//
+// Built-in themes
+//
+
+// DefaultTheme will return the theme used by loggers unless another theme is set.
+func DefaultTheme() Theme {
+	return Theme{
+		LogLevels: NewLogLevelColorMap(),
+		Tags:      NewTagColorMap(),
+		Tag:       []Attribute{FgWhite, Faint},
+	}
+}
+
+// SolarizedTheme will return a theme based on the Solarized color palette.
+// The colors are 24-bit, so they will be downgraded on terminals that cannot display them.
+func SolarizedTheme() Theme {
+	return Theme{
+		LogLevels: NewLogLevelColorMap(
+			WithLogLevelColorMapping(FatalLevel, FgHex("#fdf6e3"), BgHex("#dc322f")),
+			WithLogLevelColorMapping(ErrorLevel, FgHex("#dc322f")),
+			WithLogLevelColorMapping(WarnLevel, FgHex("#b58900")),
+			WithLogLevelColorMapping(InfoLevel, FgHex("#859900")),
+			WithLogLevelColorMapping(DebugLevel, FgHex("#2aa198")),
+			WithLogLevelColorMapping(TraceLevel, FgHex("#268bd2")),
+		),
+		Tags:      NewTagColorMap(),
+		Tag:       []Attribute{FgHex("#6c71c4")},
+		Timestamp: []Attribute{FgHex("#586e75")},
+		Separator: []Attribute{FgHex("#586e75")},
+	}
+}
+
+// HighContrastTheme will return a theme that uses bold, hi-intensity colors.
+func HighContrastTheme() Theme {
+	return Theme{
+		LogLevels: NewLogLevelColorMap(
+			WithLogLevelColorMapping(FatalLevel, Bold, FgHiWhite, BgRed),
+			WithLogLevelColorMapping(ErrorLevel, Bold, FgHiRed),
+			WithLogLevelColorMapping(WarnLevel, Bold, FgHiYellow),
+			WithLogLevelColorMapping(InfoLevel, Bold, FgHiGreen),
+			WithLogLevelColorMapping(DebugLevel, Bold, FgHiCyan),
+			WithLogLevelColorMapping(TraceLevel, Bold, FgHiBlue),
+		),
+		Tags:      NewTagColorMap(),
+		Tag:       []Attribute{Bold, FgHiWhite},
+		Timestamp: []Attribute{FgHiWhite},
+	}
+}
+
+// MonochromeTheme will return a theme that only uses font decorations.
+func MonochromeTheme() Theme {
+	return Theme{
+		LogLevels: LogLevelColorMap{
+			FatalLevel: []Attribute{Bold, ReverseVideo},
+			ErrorLevel: []Attribute{Bold, Underline},
+			WarnLevel:  []Attribute{Bold},
+			DebugLevel: []Attribute{Faint},
+			TraceLevel: []Attribute{Faint, Italic},
+		},
+		Tags: NewTagColorMap(),
+		Tag:  []Attribute{Italic},
+	}
+}
+
+//
+// Loading
+//
+
+// LoadTheme will decode a theme from a JSON document.
+// Any part of the log that is missing from the document is not styled.
+func LoadTheme(reader io.Reader) (theme Theme, err error) {
+
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+
+	if err = decoder.Decode(&theme); err != nil {
+		return Theme{}, err
+	}
+
+	// Make sure the maps can be modified
+	if theme.LogLevels == nil {
+		theme.LogLevels = LogLevelColorMap{}
+	}
+	if theme.Tags == nil {
+		theme.Tags = TagColorMap{}
+	}
+
+	return
+}
@@ -0,0 +1,123 @@
+package plog
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pd93/plog/mocks"
+)
+
+func TestLoadTheme(t *testing.T) {
+
+	// Expected output
+	expected := Theme{
+		LogLevels: LogLevelColorMap{
+			ErrorLevel: []Attribute{FgRed, Bold},
+			InfoLevel:  []Attribute{FgRGB(0x85, 0x99, 0x00)},
+		},
+		Tags:      TagColorMap{"db": []Attribute{Fg256(208)}},
+		Tag:       []Attribute{Faint},
+		Timestamp: []Attribute{FgHiBlack},
+	}
+
+	// Call the function
+	theme, err := LoadTheme(strings.NewReader(`{
+		"logLevels": {"error": ["red", "bold"], "INFO": ["#859900"]},
+		"tags": {"db": ["color-208"]},
+		"tag": ["faint"],
+		"timestamp": ["hi-black"]
+	}`))
+	if err != nil {
+		t.Error(err)
+	}
+
+	// Check if the output is correct
+	if !reflect.DeepEqual(theme, expected) {
+		t.Errorf("Incorrect output.\n\tExpected: '%v'\n\tReceived: '%v'", expected, theme)
+	}
+
+	// Invalid documents should return an error
+	for _, document := range []string{`{"tag": ["purple"]}`, `{"logLevels": {"LOUD": ["red"]}}`, `{"unknown": true}`} {
+		if _, err := LoadTheme(strings.NewReader(document)); err == nil {
+			t.Errorf("Expected an error for '%s'", document)
+		}
+	}
+}
+
+func TestLoggerTheme(t *testing.T) {
+
+	// Expected output
+	const expected = "\x1b[90m2006-01-02T15:04:05Z\x1b[0m \x1b[2m[\x1b[0m\x1b[1;31mINFO\x1b[0m\x1b[2m]\x1b[0m \x1b[2m[\x1b[0m\x1b[3m#tag1\x1b[0m\x1b[2m]\x1b[0m \x1b[1mTest string\x1b[0m\n"
+
+	// Create a logger with a custom theme
+	var buffer bytes.Buffer
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithColorLogging(true),
+		WithClock(mocks.Now),
+		WithTheme(Theme{
+			LogLevels: NewLogLevelColorMap(WithLogLevelColorMapping(InfoLevel, Bold, FgRed)),
+			Tag:       []Attribute{Italic},
+			Timestamp: []Attribute{FgHiBlack},
+			Message:   []Attribute{Bold},
+			Separator: []Attribute{Faint},
+		}),
+	)
+
+	logger.TInfo(Tags{"tag1"}, "Test string")
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", expected, output)
+	}
+}
+
+type levelStylingTest struct {
+	opts     []LoggerOption
+	expected string
+}
+
+func TestLoggerLevelStyling(t *testing.T) {
+
+	tests := []levelStylingTest{
+		{
+			[]LoggerOption{WithLevelStyling(LevelStyledTimestamp | LevelStyledMessage)},
+			"\x1b[31m2006-01-02T15:04:05Z\x1b[0m [\x1b[31mERROR\x1b[0m] \x1b[31mTest string\x1b[0m\n" +
+				"\x1b[32m2006-01-02T15:04:05Z\x1b[0m [\x1b[32mINFO\x1b[0m] \x1b[32mTest string\x1b[0m\n",
+		},
+		{
+			[]LoggerOption{WithLevelStyling(LevelStyledLine)},
+			"\x1b[31m2006-01-02T15:04:05Z [\x1b[31mERROR\x1b[0m\x1b[31m] Test string\x1b[0m\n" +
+				"\x1b[32m2006-01-02T15:04:05Z [\x1b[32mINFO\x1b[0m\x1b[32m] Test string\x1b[0m\n",
+		},
+		{
+			[]LoggerOption{WithTheme(Theme{
+				LogLevels: NewLogLevelColorMap(),
+				Message:   []Attribute{Faint},
+				Messages:  LogLevelColorMap{ErrorLevel: []Attribute{Bold}},
+				Lines:     LogLevelColorMap{ErrorLevel: []Attribute{BgRed}},
+			})},
+			"\x1b[41m2006-01-02T15:04:05Z [\x1b[31mERROR\x1b[0m\x1b[41m] \x1b[1mTest string\x1b[0m\x1b[41m\x1b[0m\n" +
+				"2006-01-02T15:04:05Z [\x1b[32mINFO\x1b[0m] \x1b[2mTest string\x1b[0m\n",
+		},
+	}
+
+	// Loop through the tests
+	for i, test := range tests {
+
+		// Create a logger
+		var buffer bytes.Buffer
+		logger := NewLogger(append([]LoggerOption{WithOutput(&buffer), WithColorLogging(true), WithClock(mocks.Now)}, test.opts...)...)
+
+		logger.Errorf("%s\n", errors.New("Test string"))
+		logger.Info("Test string")
+
+		// Check if the output is correct
+		if output := buffer.String(); output != test.expected {
+			t.Errorf("[%d] Incorrect output.\n\tExpected: '%q'\n\tReceived: '%q'", i, test.expected, output)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package plog
+
+import (
+	"time"
+)
+
+//
+// Structures
+//
+
+// A Timer measures how long an operation takes and logs the duration when it is stopped.
+// The duration is logged as a field with the key 'duration' so that structured formatters can keep its value.
+type Timer struct {
+	logger    *Logger // The logger to write to (nil writes to the global loggers)
+	name      string
+	tags      Tags
+	logLevel  LogLevel
+	threshold time.Duration
+	clock     Clock
+	start     time.Time
+	duration  time.Duration
+	stopped   bool
+}
+
+//
+// Constructors
+//
+
+// newTimer creates and starts a new timer.
+func newTimer(logger *Logger, name string, tags Tags, logLevel LogLevel, threshold time.Duration, clock Clock) *Timer {
+	return &Timer{
+		logger:    logger,
+		name:      name,
+		tags:      tags,
+		logLevel:  logLevel,
+		threshold: threshold,
+		clock:     clock,
+		start:     clock(),
+	}
+}
+
+// NewTimer creates and starts a timer that writes to all loggers when it is stopped.
+// The duration is logged at info level, or at warn level if it is over the threshold.
+func NewTimer(name string, tags ...Tag) *Timer {
+	return newTimer(nil, name, tags, InfoLevel, 0, time.Now)
+}
+
+// Track starts a timer that writes to all loggers and returns a function that stops it.
+// This allows an operation to be timed in a single line: `defer plog.Track("operation")()`.
+func Track(name string, tags ...Tag) func() {
+	return NewTimer(name, tags...).stop
+}
+
+// Timer creates and starts a timer that writes to the logger when it is stopped.
+// The log level and threshold of the timer are set by the logger's timer options.
+func (logger *Logger) Timer(name string, tags ...Tag) *Timer {
+	return newTimer(logger, name, tags, logger.timerLogLevel, logger.timerThreshold, logger.clock)
+}
+
+// Track starts a timer that writes to the logger and returns a function that stops it.
+// This allows an operation to be timed in a single line: `defer logger.Track("operation")()`.
+func (logger *Logger) Track(name string, tags ...Tag) func() {
+	return logger.Timer(name, tags...).stop
+}
+
+// Timer creates and starts a nested timer.
+// The nested timer inherits the settings and tags of its parent and its name is prefixed with the parent's name.
+func (timer *Timer) Timer(name string, tags ...Tag) *Timer {
+	return newTimer(timer.logger, timer.name+"/"+name, append(append(Tags(nil), timer.tags...), tags...), timer.logLevel, timer.threshold, timer.clock)
+}
+
+// Track starts a nested timer and returns a function that stops it.
+func (timer *Timer) Track(name string, tags ...Tag) func() {
+	return timer.Timer(name, tags...).stop
+}
+
+//
+// Getters
+//
+
+// Name will return the name of the timer.
+func (timer *Timer) Name() string {
+	return timer.name
+}
+
+// Elapsed will return the time since the timer was started, or the final duration if it has been stopped.
+func (timer *Timer) Elapsed() time.Duration {
+	if timer.stopped {
+		return timer.duration
+	}
+	return timer.clock().Sub(timer.start)
+}
+
+//
+// Instance methods
+//
+
+// Stop will stop the timer and log its duration.
+// Stopping a timer more than once has no effect and returns the original duration.
+func (timer *Timer) Stop() time.Duration {
+	return timer.StopWithError(nil)
+}
+
+// StopWithError will stop the timer and log its duration.
+// If the error is not nil, it is added to the log as a field with the key 'error' and the log is written at error level.
+// Stopping a timer more than once has no effect and returns the original duration.
+func (timer *Timer) StopWithError(err error) time.Duration {
+
+	if timer.stopped {
+		return timer.duration
+	}
+
+	timer.duration = timer.clock().Sub(timer.start)
+	timer.stopped = true
+
+	logLevel := timer.logLevel
+
+	// Escalate the log level if the timer took too long
+	if timer.threshold > 0 && timer.duration > timer.threshold && logLevel > WarnLevel {
+		logLevel = WarnLevel
+	}
+
+	// Escalate the log level if the operation failed
+	if err != nil && logLevel > ErrorLevel {
+		logLevel = ErrorLevel
+	}
+
+	variables := []interface{}{timer.name, NewField("duration", timer.duration)}
+	if err != nil {
+		variables = append(variables, NewField("error", err))
+	}
+
+	// Write the log
+	if timer.logger != nil {
+		if timer.logger.check(logLevel) {
+			timer.logger.write(newTLog(logLevel, timer.tags, variables...))
+		}
+	} else if loggers.check(logLevel) {
+		loggers.write(newTLog(logLevel, timer.tags, variables...))
+	}
+
+	return timer.duration
+}
+
+// stop will stop the timer without returning the duration.
+func (timer *Timer) stop() {
+	timer.Stop()
+}
@@ -0,0 +1,99 @@
+package plog
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pd93/plog/formatters"
+	"github.com/pd93/plog/mocks"
+)
+
+func TestTimer(t *testing.T) {
+
+	// Expected output
+	const expected = "" +
+		"2006-01-02T15:04:06Z [INFO] [#db] db.query duration=1s\n" +
+		"2006-01-02T15:04:10Z [WARN] [#db] db.query duration=3s\n" +
+		"2006-01-02T15:04:11Z [ERROR] [#db] db.query duration=1s error=Test error\n" +
+		"2006-01-02T15:04:12Z [INFO] [#db #tx] db.query/commit duration=1s\n" +
+		"2006-01-02T15:04:12Z [INFO] [#db] db.query duration=1s\n"
+
+	// Create a logger with a fake clock
+	var buffer bytes.Buffer
+	clock := mocks.NewClock(mocks.Now())
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithColorLogging(false),
+		WithClock(clock.Now),
+		WithTimerThreshold(2*time.Second),
+	)
+
+	// Under the threshold
+	timer := logger.Timer("db.query", "db")
+	clock.Advance(time.Second)
+	if d := timer.Stop(); d != time.Second {
+		t.Errorf("Incorrect duration. Expected '%s', received '%s'", time.Second, d)
+	}
+
+	// Stopping a timer twice should have no effect
+	clock.Advance(time.Second)
+	if d := timer.Stop(); d != time.Second {
+		t.Errorf("Incorrect duration. Expected '%s', received '%s'", time.Second, d)
+	}
+
+	// Over the threshold
+	timer = logger.Timer("db.query", "db")
+	clock.Advance(3 * time.Second)
+	timer.Stop()
+
+	// With an error
+	timer = logger.Timer("db.query", "db")
+	clock.Advance(time.Second)
+	timer.StopWithError(errors.New("Test error"))
+
+	// Nested timers
+	func() {
+		defer logger.Track("db.query", "db")()
+		func() {
+			defer logger.Timer("db.query", "db").Track("commit", "tx")()
+			clock.Advance(time.Second)
+		}()
+	}()
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}
+
+func TestTimerJSON(t *testing.T) {
+
+	// Expected output
+	const expected = `{"timestamp":"2006-01-02T15:04:06Z","logLevel":"DEBUG","variables":["op",{"key":"duration","value":1500000000}]}` + "\n"
+
+	// Create a logger with a fake clock
+	var buffer bytes.Buffer
+	clock := mocks.NewClock(mocks.Now())
+	logger := NewLogger(
+		WithOutput(&buffer),
+		WithRecordFormatter(formatters.JSONRecord),
+		WithLogLevel(DebugLevel),
+		WithClock(clock.Now),
+		WithTimerLogLevel(DebugLevel),
+	)
+
+	// The timer should measure the duration using the fake clock
+	timer := logger.Timer("op")
+	clock.Advance(1500 * time.Millisecond)
+	if elapsed := timer.Elapsed(); elapsed != 1500*time.Millisecond {
+		t.Errorf("Incorrect elapsed time. Expected '1.5s', received '%s'", elapsed)
+	}
+	timer.Stop()
+
+	// Check if the output is correct
+	if output := buffer.String(); output != expected {
+		t.Errorf("Incorrect output.\n\tExpected: '%s'\n\tReceived: '%s'", expected, output)
+	}
+}